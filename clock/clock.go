@@ -0,0 +1,30 @@
+package clock
+
+import "time"
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Clock tells the current time.
+*/
+type Clock interface {
+	Now() time.Time
+}
+
+/*
+Real is the [Clock] of the host, as reported by [time.Now].
+*/
+type Real struct{}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Now returns the current local time.
+*/
+func (Real) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,40 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestReal_Now(test *testing.T) {
+	test.Parallel()
+
+	var aClock clock.Clock = clock.Real{}
+
+	before := time.Now()
+	actual := aClock.Now()
+	assert.False(test, actual.Before(before))
+	assert.False(test, actual.After(time.Now()))
+}
+
+func TestFake_Now(test *testing.T) {
+	test.Parallel()
+
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	aClock := clock.NewFake(start)
+	assert.Equal(test, start, aClock.Now())
+
+	aClock.Advance(90 * time.Minute)
+	assert.Equal(test, start.Add(90*time.Minute), aClock.Now())
+
+	aClock.Set(start.AddDate(1, 0, 0))
+	assert.Equal(test, start.AddDate(1, 0, 0), aClock.Now())
+
+	assert.True(test, (&clock.Fake{}).Now().IsZero())
+}
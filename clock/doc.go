@@ -0,0 +1,7 @@
+/*
+Package clock supplies the current time to the mock clients.
+
+Time-dependent mock behavior, such as license expiry, reads the time from a [Clock]
+so that tests can substitute their own.
+*/
+package clock
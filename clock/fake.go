@@ -0,0 +1,75 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Fake is a [Clock] that only moves when told to.
+
+The zero value tells the zero time.
+A Fake is safe for concurrent use.
+*/
+type Fake struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function NewFake returns a [Fake] that tells a given time.
+
+Input
+  - now: The time the clock tells until it is advanced or set.
+*/
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Advance moves the clock forward.
+
+Input
+  - duration: How far to move the clock. A negative duration moves it back.
+*/
+func (aClock *Fake) Advance(duration time.Duration) {
+	aClock.mutex.Lock()
+	defer aClock.mutex.Unlock()
+
+	aClock.now = aClock.now.Add(duration)
+}
+
+/*
+Method Now returns the time the clock tells.
+*/
+func (aClock *Fake) Now() time.Time {
+	aClock.mutex.Lock()
+	defer aClock.mutex.Unlock()
+
+	return aClock.now
+}
+
+/*
+Method Set moves the clock to a given time.
+
+Input
+  - now: The time the clock tells from now on.
+*/
+func (aClock *Fake) Set(now time.Time) {
+	aClock.mutex.Lock()
+	defer aClock.mutex.Unlock()
+
+	aClock.now = now
+}
@@ -0,0 +1,12 @@
+/*
+Package faults injects errors and latency into the calls of the mock clients.
+
+An [Injector] is consulted at the start of every call.
+Its [Chaos] decides how often the call fails, with which kinds of [szerror] errors,
+and how much latency the call suffers.
+During an [Outage], such as a lost database connection or a crashed engine, every call fails instead.
+Every random draw comes from a seeded [random.Source], so a chaotic run can be reproduced from its seed.
+
+[szerror]: https://pkg.go.dev/github.com/senzing-garage/sz-sdk-go/szerror
+*/
+package faults
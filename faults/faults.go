@@ -0,0 +1,218 @@
+package faults
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Chaos describes the faults injected into calls.
+
+The zero value injects nothing.
+*/
+type Chaos struct {
+	ErrorRate      float64       // Share of calls failing, from 0.0 to 1.0.
+	P99Latency     time.Duration // 99th percentile of the latency added to each call. If 0, no latency is added.
+	RetryableShare float64       // Share of failures that are retryable. The rest are bad input or unrecoverable.
+}
+
+/*
+Injector injects the faults of a [Chaos] into calls, and fails every call during an [Outage].
+
+A nil Injector injects nothing.
+An Injector is safe for concurrent use by the clients sharing it.
+*/
+type Injector struct {
+	Chaos   Chaos            // Faults of every method not in Methods.
+	Clock   clock.Clock      // Time of outages. If nil, the time of the host.
+	Methods map[string]Chaos // Faults by method name, e.g. "addRecord", overriding Chaos.
+	Random  *random.Source   // Source of every random draw. If nil, a source is made by random.NewFromEnvironment.
+
+	mutex       sync.Mutex
+	outage      *Outage
+	outageCalls int64 // Calls made since the outage was started, up to its After.
+}
+
+// A fault is an error an injector may return.
+type fault struct {
+	code    int
+	message string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	badInputErrorCode = 2    // The native error code of invalid arguments, typed as a bad input error.
+	badInputShare     = 0.5  // Share of non-retryable failures that are bad input.
+	percentile99      = 0.99 // Share of latencies not exceeding P99Latency.
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+/*
+Soak is the chaos of a long-running soak test:
+2% of calls fail, mostly with retryable errors, and 1% of calls take longer than 200ms.
+*/
+var Soak = Chaos{
+	ErrorRate:      0.02,
+	P99Latency:     200 * time.Millisecond,
+	RetryableShare: 0.8,
+}
+
+var (
+	badInputFaults = []fault{
+		{code: 2, message: "Invalid Message"},
+		{code: 7, message: "Empty Message"},
+	}
+	retryableFaults = []fault{
+		{code: 10, message: "Retry timeout exceeded resolved entity locklist"},
+		{code: 1007, message: "Database Connection Lost"},
+		{code: 1008, message: "Deadlock Error"},
+	}
+	unrecoverableFaults = []fault{
+		{code: 87, message: "Sz Exception"},
+		{code: 1001, message: "Critical Database Error"},
+	}
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Inject subjects a call to the outage of the injector and the chaos of its method.
+
+During an outage, the call fails at once with the error of the outage.
+Otherwise the call is first delayed by a latency drawn from an exponential distribution
+whose 99th percentile is P99Latency.
+It then fails with probability ErrorRate.
+A failure is an SzRetryableError with probability RetryableShare,
+otherwise an SzBadInputError or an SzUnrecoverableError with equal probability.
+
+Input
+  - ctx: A context to control lifecycle. If it is done during the latency, its error is returned.
+  - method: The name of the method called, e.g. "addRecord".
+
+Output
+  - The error the call fails with, or nil.
+*/
+func (injector *Injector) Inject(ctx context.Context, method string) error {
+	if injector == nil {
+		return nil
+	}
+
+	chaos, source, err := injector.prepare(method)
+	if err != nil {
+		return err
+	}
+
+	if chaos == (Chaos{}) {
+		return nil
+	}
+
+	if chaos.P99Latency > 0 {
+		err = sleep(ctx, exponential(source, chaos.P99Latency))
+		if err != nil {
+			return err
+		}
+	}
+
+	if chaos.ErrorRate <= 0 || source.Float64() >= chaos.ErrorRate {
+		return nil
+	}
+
+	faults := unrecoverableFaults
+
+	switch {
+	case source.Float64() < chaos.RetryableShare:
+		faults = retryableFaults
+	case source.Float64() < badInputShare:
+		faults = badInputFaults
+	}
+
+	return faults[source.Int64N(int64(len(faults)))].error(method)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the chaos of a method and the source of random draws, making the source if needed.
+// During an outage, return its error instead.
+func (injector *Injector) prepare(method string) (Chaos, *random.Source, error) {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	if aFault, isFailing := injector.outageFault(); isFailing {
+		return Chaos{}, nil, aFault.error(method)
+	}
+
+	chaos, isOverridden := injector.Methods[method]
+	if !isOverridden {
+		chaos = injector.Chaos
+	}
+
+	if chaos == (Chaos{}) {
+		return chaos, nil, nil
+	}
+
+	if injector.Random == nil {
+		source, err := random.NewFromEnvironment()
+		if err != nil {
+			return Chaos{}, nil, err
+		}
+
+		injector.Random = source
+	}
+
+	return chaos, injector.Random, nil
+}
+
+func (aFault fault) error(method string) error {
+	message, _ := json.Marshal(struct {
+		Reason string `json:"reason"`
+	}{
+		Reason: fmt.Sprintf("SENZ%04d|%s 'injected into %s'", aFault.code, aFault.message, method),
+	})
+
+	return szerror.New(aFault.code, string(message))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Draw a duration from the exponential distribution with a given 99th percentile.
+func exponential(source *random.Source, p99 time.Duration) time.Duration {
+	mean := float64(p99) / math.Log(1/(1-percentile99))
+
+	return time.Duration(-mean * math.Log(1-source.Float64()))
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
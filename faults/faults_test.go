@@ -0,0 +1,118 @@
+package faults_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const calls = 1000
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestInjector_Inject(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	source := random.New(42)
+	source.ReportSeed(test)
+	injector := &faults.Injector{Chaos: faults.Chaos{ErrorRate: 0.1, RetryableShare: 0.5}, Random: source}
+
+	counts := map[string]int{}
+
+	for range calls {
+		err := injector.Inject(ctx, "addRecord")
+
+		switch {
+		case err == nil:
+			counts["none"]++
+		case errors.Is(err, szerror.ErrSzRetryable):
+			counts["retryable"]++
+		case errors.Is(err, szerror.ErrSzBadInput):
+			counts["badInput"]++
+		case errors.Is(err, szerror.ErrSzUnrecoverable):
+			counts["unrecoverable"]++
+		default:
+			require.Fail(test, "unexpected error", err)
+		}
+	}
+
+	assert.InDelta(test, 900, counts["none"], 30)
+	assert.InDelta(test, 50, counts["retryable"], 20)
+	assert.Positive(test, counts["badInput"])
+	assert.Positive(test, counts["unrecoverable"])
+}
+
+func TestInjector_Inject_cancelled(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	cancel()
+
+	injector := &faults.Injector{Chaos: faults.Chaos{P99Latency: time.Hour}, Random: random.New(1)}
+	err := injector.Inject(ctx, "addRecord")
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestInjector_Inject_methods(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	injector := &faults.Injector{
+		Chaos:   faults.Chaos{ErrorRate: 1, RetryableShare: 1},
+		Methods: map[string]faults.Chaos{"destroy": {}},
+		Random:  random.New(1),
+	}
+
+	require.ErrorIs(test, injector.Inject(ctx, "addRecord"), szerror.ErrSzRetryable)
+	require.NoError(test, injector.Inject(ctx, "destroy"))
+}
+
+func TestInjector_Inject_nil(test *testing.T) {
+	test.Parallel()
+
+	var injector *faults.Injector
+
+	require.NoError(test, injector.Inject(test.Context(), "addRecord"))
+}
+
+func TestInjector_Inject_reproducible(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	errorMessages := func() []string {
+		injector := &faults.Injector{Chaos: faults.Chaos{ErrorRate: 0.5, RetryableShare: 0.5}, Random: random.New(7)}
+		result := []string{}
+
+		for range 100 {
+			err := injector.Inject(ctx, "addRecord")
+			if err != nil {
+				result = append(result, err.Error())
+			} else {
+				result = append(result, "")
+			}
+		}
+
+		return result
+	}
+
+	assert.Equal(test, errorMessages(), errorMessages())
+}
+
+func TestInjector_Inject_unrecoverable(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	injector := &faults.Injector{Chaos: faults.Chaos{ErrorRate: 1}, Random: random.New(3)}
+
+	for range 100 {
+		err := injector.Inject(ctx, "addRecord")
+		require.Error(test, err)
+		require.NotErrorIs(test, err, szerror.ErrSzRetryable)
+		require.True(test, errors.Is(err, szerror.ErrSzBadInput) || errors.Is(err, szerror.ErrSzUnrecoverable))
+	}
+}
@@ -0,0 +1,145 @@
+package faults
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Outage describes a failure of the whole engine, which fails every call until it ends.
+
+An outage begins once After calls are made since it was started, and the clock of the injector reaches At.
+The zero value of both begins the outage with the next call.
+*/
+type Outage struct {
+	After int64      // Calls succeeding before the outage begins.
+	At    time.Time  // If not zero, the outage does not begin before this time.
+	Kind  OutageKind // What fails. If 0, DatabaseUnavailable.
+}
+
+/*
+OutageKind tells what fails in an [Outage].
+*/
+type OutageKind int
+
+// The kind of an outage decides the error of every failing call.
+const (
+	// DatabaseUnavailable fails calls with an SzDatabaseConnectionLostError, which is retryable.
+	DatabaseUnavailable OutageKind = iota + 1
+
+	// EngineCrash fails calls with an SzUnhandledError, which is unrecoverable.
+	EngineCrash
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var outageFaults = map[OutageKind]fault{
+	DatabaseUnavailable: {code: 1006, message: "Database Connection Failure"},
+	EngineCrash:         {code: 87, message: "Sz Exception"},
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method EndOutage ends the outage of the injector, if any.
+A nil Injector has no outage to end.
+*/
+func (injector *Injector) EndOutage() {
+	if injector == nil {
+		return
+	}
+
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	injector.outage = nil
+}
+
+/*
+Method StartOutage starts an outage, replacing the outage of the injector, if any.
+Once the outage begins, every call fails until [Injector.EndOutage] is called.
+
+Input
+  - outage: The outage, e.g. Outage{After: 100, Kind: EngineCrash}.
+
+Output
+  - An SzBadInputError if the kind of the outage is unknown, or if the Injector is nil, as it injects nothing.
+*/
+func (injector *Injector) StartOutage(outage Outage) error {
+	if outage.Kind == 0 {
+		outage.Kind = DatabaseUnavailable
+	}
+
+	if _, isKnown := outageFaults[outage.Kind]; !isKnown {
+		return newBadInputError("Unknown outage kind %d", outage.Kind)
+	}
+
+	if injector == nil {
+		return newBadInputError("Cannot start an outage of a nil injector")
+	}
+
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	injector.outage = &outage
+	injector.outageCalls = 0
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Count a call against the outage of the injector, returning the fault the call suffers, if any.
+// The caller holds the mutex.
+func (injector *Injector) outageFault() (fault, bool) {
+	if injector.outage == nil {
+		return fault{}, false
+	}
+
+	if injector.outageCalls < injector.outage.After {
+		injector.outageCalls++
+
+		return fault{}, false
+	}
+
+	if !injector.outage.At.IsZero() && injector.now().Before(injector.outage.At) {
+		return fault{}, false
+	}
+
+	return outageFaults[injector.outage.Kind], true
+}
+
+func (injector *Injector) now() time.Time {
+	if injector.Clock == nil {
+		return time.Now()
+	}
+
+	return injector.Clock.Now()
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newBadInputError(format string, args ...any) error {
+	message, _ := json.Marshal(struct {
+		Reason string `json:"reason"`
+	}{
+		Reason: fmt.Sprintf(format, args...),
+	})
+
+	return szerror.New(badInputErrorCode, string(message))
+}
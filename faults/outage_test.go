@@ -0,0 +1,98 @@
+package faults_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestInjector_StartOutage(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	injector := &faults.Injector{}
+
+	require.NoError(test, injector.StartOutage(faults.Outage{Kind: faults.DatabaseUnavailable}))
+
+	err := injector.Inject(ctx, "addRecord")
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+
+	injector.EndOutage()
+	require.NoError(test, injector.Inject(ctx, "addRecord"))
+}
+
+func TestInjector_StartOutage_after(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	injector := &faults.Injector{}
+	require.NoError(test, injector.Inject(ctx, "addRecord"))
+
+	require.NoError(test, injector.StartOutage(faults.Outage{After: 3, Kind: faults.EngineCrash}))
+
+	for range 3 {
+		require.NoError(test, injector.Inject(ctx, "addRecord"))
+	}
+
+	for range 3 {
+		err := injector.Inject(ctx, "getEntityByRecordID")
+		require.ErrorIs(test, err, szerror.ErrSzUnhandled)
+		require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+	}
+}
+
+func TestInjector_StartOutage_at(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	aClock := clock.NewFake(start)
+	injector := &faults.Injector{Clock: aClock}
+
+	require.NoError(test, injector.StartOutage(faults.Outage{At: start.Add(time.Minute), Kind: faults.EngineCrash}))
+	require.NoError(test, injector.Inject(ctx, "addRecord"))
+
+	aClock.Advance(time.Minute)
+	require.ErrorIs(test, injector.Inject(ctx, "addRecord"), szerror.ErrSzUnrecoverable)
+
+	require.NoError(test, injector.StartOutage(faults.Outage{Kind: faults.DatabaseUnavailable}))
+	require.ErrorIs(test, injector.Inject(ctx, "addRecord"), szerror.ErrSzDatabaseConnectionLost)
+}
+
+func TestInjector_StartOutage_defaultKind(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	injector := &faults.Injector{}
+
+	require.NoError(test, injector.StartOutage(faults.Outage{After: 1}))
+	require.NoError(test, injector.Inject(ctx, "addRecord"))
+
+	err := injector.Inject(ctx, "addRecord")
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+}
+
+func TestInjector_StartOutage_nilInjector(test *testing.T) {
+	test.Parallel()
+
+	var injector *faults.Injector
+
+	err := injector.StartOutage(faults.Outage{Kind: faults.EngineCrash})
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.NotPanics(test, injector.EndOutage)
+}
+
+func TestInjector_StartOutage_unknownKind(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	injector := &faults.Injector{}
+
+	err := injector.StartOutage(faults.Outage{Kind: faults.OutageKind(7)})
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.NoError(test, injector.Inject(ctx, "addRecord"))
+}
@@ -0,0 +1,64 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+)
+
+/*
+The Notify function sends a message to the observers of a subject.
+
+It builds the same message as notifier.Notify of go-observing,
+except that the "messageTime" comes from a [clock.Clock].
+
+Input
+  - ctx: A context to control lifecycle.
+  - aClock: The clock telling the message time.
+  - aSubject: The subject whose observers are notified. If nil, nothing is sent.
+  - origin: The "origin" of the message. If empty, the message has no origin.
+  - subjectID: The component ID of the sender.
+  - messageID: The identifier of the message.
+  - err: The error of the call, if any.
+  - details: Further key/value pairs of the message.
+*/
+func Notify(
+	ctx context.Context,
+	aClock clock.Clock,
+	aSubject subject.Subject,
+	origin string,
+	subjectID int,
+	messageID int,
+	err error,
+	details map[string]string,
+) {
+	if aSubject == nil {
+		return
+	}
+
+	if len(origin) > 0 {
+		details["origin"] = origin
+	}
+
+	details["subjectId"] = strconv.Itoa(subjectID)
+	details["messageId"] = strconv.Itoa(messageID)
+	details["messageTime"] = aClock.Now().UTC().Format(time.RFC3339Nano)
+
+	if err != nil {
+		details["error"] = err.Error()
+	}
+
+	message, err := json.Marshal(details)
+	if err != nil {
+		panic(err)
+	}
+
+	err = aSubject.NotifyObservers(ctx, string(message))
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+package helper_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A messageRecorder is an observer keeping the messages it receives.
+type messageRecorder struct {
+	messages []string
+}
+
+func (recorder *messageRecorder) GetObserverID(ctx context.Context) string {
+	_ = ctx
+
+	return "messageRecorder"
+}
+
+func (recorder *messageRecorder) UpdateObserver(ctx context.Context, message string) {
+	_ = ctx
+	recorder.messages = append(recorder.messages, message)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestHelpers_Notify(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aClock := clock.NewFake(time.Date(2025, time.March, 4, 5, 6, 7, 0, time.UTC))
+	aSubject := subject.NewSimpleSubject()
+	recorder := &messageRecorder{}
+	require.NoError(test, aSubject.RegisterObserver(ctx, recorder))
+
+	helper.Notify(ctx, aClock, aSubject, "test origin", 6036, 8001, errors.New("failed"), map[string]string{"a": "b"})
+	require.Len(test, recorder.messages, 1)
+
+	actual := map[string]string{}
+	require.NoError(test, json.Unmarshal([]byte(recorder.messages[0]), &actual))
+	assert.Equal(
+		test,
+		map[string]string{
+			"a":           "b",
+			"error":       "failed",
+			"messageId":   "8001",
+			"messageTime": "2025-03-04T05:06:07Z",
+			"origin":      "test origin",
+			"subjectId":   "6036",
+		},
+		actual,
+	)
+
+	helper.Notify(ctx, aClock, nil, "test origin", 6036, 8001, nil, map[string]string{})
+	assert.Len(test, recorder.messages, 1)
+}
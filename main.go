@@ -2,15 +2,15 @@ package main
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
 	"log"
-	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/senzing-garage/go-helpers/truthset"
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
 	"github.com/senzing-garage/sz-sdk-go-mock/szabstractfactory"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
 )
@@ -32,6 +32,7 @@ var Messages = map[int]string{
 	2002: "Physical cores: %d.",
 	2003: "withInfo",
 	2004: "License",
+	2005: "Random: %s",
 	2999: "Cannot retrieve last error message.",
 }
 
@@ -67,12 +68,18 @@ func main() {
 		"BuildIteration": buildIteration,
 	}
 
+	// Seed everything random, reproducibly via SZ_MOCK_SEED.
+
+	source, err := random.NewFromEnvironment()
+	failOnError(5001, err)
+
 	// Create a SzAbstractFactory.
 
-	szAbstractFactory := &szabstractfactory.Szabstractfactory{}
+	szAbstractFactory := &szabstractfactory.Szabstractfactory{Random: source}
 
 	outputf("\n-------------------------------------------------------------------------------\n\n")
 	logger.Log(2001, "Just a test of logging", programmMetadataMap)
+	logger.Log(2005, source)
 
 	// Demonstrate persisting a Senzing configuration to the Senzing repository.
 
@@ -80,7 +87,7 @@ func main() {
 
 	// Demonstrate tests.
 
-	demonstrateAdditionalFunctions(ctx, szAbstractFactory)
+	demonstrateAdditionalFunctions(ctx, szAbstractFactory, source)
 
 	err = szAbstractFactory.Close(ctx)
 	failOnError(5008, err)
@@ -92,12 +99,16 @@ func main() {
 // Demonstrations
 // ----------------------------------------------------------------------------
 
-func demonstrateAdditionalFunctions(ctx context.Context, szAbstractFactory senzing.SzAbstractFactory) {
+func demonstrateAdditionalFunctions(
+	ctx context.Context,
+	szAbstractFactory senzing.SzAbstractFactory,
+	source *random.Source,
+) {
 	// Using SzEngine: Add records with information returned.
 	szEngine, err := szAbstractFactory.CreateEngine(ctx)
 	failOnError(5100, err)
 
-	withInfo, err := demonstrateAddRecord(ctx, szEngine)
+	withInfo, err := demonstrateAddRecord(ctx, szEngine, source)
 	failOnError(5101, err)
 	logger.Log(2101, withInfo)
 
@@ -111,12 +122,9 @@ func demonstrateAdditionalFunctions(ctx context.Context, szAbstractFactory senzi
 	logger.Log(2102, license)
 }
 
-func demonstrateAddRecord(ctx context.Context, szEngine senzing.SzEngine) (string, error) {
+func demonstrateAddRecord(ctx context.Context, szEngine senzing.SzEngine, source *random.Source) (string, error) {
 	dataSourceCode := "TEST"
-	randomNumber, err := rand.Int(rand.Reader, big.NewInt(1000000000))
-	failOnError(5201, err)
-
-	recordID := randomNumber.String()
+	recordID := strconv.FormatInt(source.Int64N(1000000000), 10)
 	recordDefinition := fmt.Sprintf(
 		"%s%s%s",
 		`{"SOCIAL_HANDLE": "flavorh", "DATE_OF_BIRTH": "4/8/1983", "ADDR_STATE": "LA", "ADDR_POSTAL_CODE": "71232", "SSN_NUMBER": "053-39-3251", "ENTITY_TYPE": "TEST", "GENDER": "F", "srccode": "MDMPER", "CC_ACCOUNT_NUMBER": "5534202208773608", "RECORD_ID": "`,
@@ -0,0 +1,8 @@
+/*
+Package random supplies the pseudo-random numbers of the mock clients.
+
+Randomized mock behavior, such as entity ID assignment, draws from a seeded [Source]
+so that a run can be reproduced from its seed.
+Setting the SZ_MOCK_SEED environment variable fixes the seed of [NewFromEnvironment].
+*/
+package random
@@ -0,0 +1,170 @@
+package random
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Source is a seeded generator of pseudo-random numbers.
+
+Two sources with the same seed produce the same numbers when called in the same order.
+A Source is safe for concurrent use.
+*/
+type Source struct {
+	generator *rand.Rand
+	mutex     sync.Mutex
+	seed      uint64
+}
+
+/*
+TB is the part of [testing.TB] used by [Source.ReportSeed].
+*/
+type TB interface {
+	Cleanup(f func())
+	Failed() bool
+	Logf(format string, args ...any)
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const badInputErrorCode = 2
+
+// SeedEnvVar is the environment variable fixing the seed of [NewFromEnvironment].
+const SeedEnvVar = "SZ_MOCK_SEED"
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+Function New returns a [Source] with a given seed.
+
+Input
+  - seed: The seed of the generator.
+*/
+func New(seed uint64) *Source {
+	return &Source{
+		generator: rand.New(rand.NewPCG(seed, seed)), //nolint:gosec // Reproducible, not secure.
+		seed:      seed,
+	}
+}
+
+/*
+Function IsSeededByEnvironment reports whether SZ_MOCK_SEED is set, so that [NewFromEnvironment] is reproducible.
+*/
+func IsSeededByEnvironment() bool {
+	_, isSet := os.LookupEnv(SeedEnvVar)
+
+	return isSet
+}
+
+/*
+Function NewFromEnvironment returns a [Source] seeded by SZ_MOCK_SEED.
+If SZ_MOCK_SEED is not set, the seed is itself random.
+
+Output
+  - A Source.
+  - An SzBadInputError if SZ_MOCK_SEED is not an unsigned integer.
+*/
+func NewFromEnvironment() (*Source, error) {
+	value, isSet := os.LookupEnv(SeedEnvVar)
+	if !isSet {
+		return New(rand.Uint64()), nil //nolint:gosec // Only the seed.
+	}
+
+	seed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		message, _ := json.Marshal(struct {
+			Reason string `json:"reason"`
+		}{
+			Reason: fmt.Sprintf("Invalid %s '%s'; expected an unsigned integer: %v", SeedEnvVar, value, err),
+		})
+
+		return nil, szerror.New(badInputErrorCode, string(message))
+	}
+
+	return New(seed), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method Duration returns a pseudo-random duration in the half-open interval [0, maximum).
+
+Input
+  - maximum: The upper bound. If not positive, the result is 0.
+*/
+func (source *Source) Duration(maximum time.Duration) time.Duration {
+	return time.Duration(source.Int64N(int64(maximum)))
+}
+
+/*
+Method Float64 returns a pseudo-random number in the half-open interval [0.0, 1.0).
+*/
+func (source *Source) Float64() float64 {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return source.generator.Float64()
+}
+
+/*
+Method Int64N returns a pseudo-random number in the half-open interval [0, n).
+
+Input
+  - n: The upper bound. If not positive, the result is 0.
+*/
+func (source *Source) Int64N(n int64) int64 {
+	if n <= 0 {
+		return 0
+	}
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return source.generator.Int64N(n)
+}
+
+/*
+Method ReportSeed logs how to reproduce the run of a test, should the test fail.
+
+Input
+  - tb: The test, benchmark or fuzz target drawing from the source.
+*/
+func (source *Source) ReportSeed(tb TB) {
+	tb.Cleanup(func() {
+		if tb.Failed() {
+			tb.Logf("reproduce with %s", source)
+		}
+	})
+}
+
+/*
+Method Seed returns the seed of the source.
+*/
+func (source *Source) Seed() uint64 {
+	return source.seed
+}
+
+/*
+Method String returns the environment setting reproducing the source, e.g. "SZ_MOCK_SEED=42".
+*/
+func (source *Source) String() string {
+	return fmt.Sprintf("%s=%d", SeedEnvVar, source.seed)
+}
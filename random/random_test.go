@@ -0,0 +1,100 @@
+package random_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A failingTB is a test that has failed, keeping what it logs.
+type failingTB struct {
+	cleanups []func()
+	logs     []string
+}
+
+func (tb *failingTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *failingTB) Failed() bool {
+	return true
+}
+
+func (tb *failingTB) Logf(format string, args ...any) {
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestIsSeededByEnvironment(test *testing.T) {
+	test.Setenv(random.SeedEnvVar, "1234")
+	assert.True(test, random.IsSeededByEnvironment())
+
+	require.NoError(test, os.Unsetenv(random.SeedEnvVar))
+	assert.False(test, random.IsSeededByEnvironment())
+}
+
+func TestNew(test *testing.T) {
+	test.Parallel()
+
+	source1 := random.New(42)
+	source2 := random.New(42)
+
+	for range 100 {
+		assert.Equal(test, source1.Float64(), source2.Float64())
+		assert.Equal(test, source1.Int64N(1000), source2.Int64N(1000))
+		assert.Equal(test, source1.Duration(time.Second), source2.Duration(time.Second))
+	}
+
+	assert.Equal(test, uint64(42), source1.Seed())
+	assert.Equal(test, "SZ_MOCK_SEED=42", source1.String())
+}
+
+func TestNewFromEnvironment(test *testing.T) {
+	test.Setenv(random.SeedEnvVar, "1234")
+
+	source, err := random.NewFromEnvironment()
+	require.NoError(test, err)
+	assert.Equal(test, uint64(1234), source.Seed())
+	assert.Equal(test, random.New(1234).Float64(), source.Float64())
+}
+
+func TestNewFromEnvironment_badSeed(test *testing.T) {
+	test.Setenv(random.SeedEnvVar, "-1")
+
+	_, err := random.NewFromEnvironment()
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSource_Int64N(test *testing.T) {
+	test.Parallel()
+
+	source := random.New(7)
+	assert.Equal(test, int64(0), source.Int64N(0))
+	assert.Equal(test, time.Duration(0), source.Duration(-time.Second))
+
+	for range 100 {
+		value := source.Int64N(10)
+		assert.GreaterOrEqual(test, value, int64(0))
+		assert.Less(test, value, int64(10))
+	}
+}
+
+func TestSource_ReportSeed(test *testing.T) {
+	test.Parallel()
+
+	tb := &failingTB{}
+	random.New(99).ReportSeed(tb)
+	require.Len(test, tb.cleanups, 1)
+
+	tb.cleanups[0]()
+	assert.Equal(test, []string{"reproduce with SZ_MOCK_SEED=99"}, tb.logs)
+}
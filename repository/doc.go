@@ -0,0 +1,11 @@
+/*
+Package repository is an in-memory simulation of a Senzing repository.
+
+A [Repository] gives the mock clients state.
+Records added through the mock SzEngine are kept, resolved into entities by a small rule-based resolver,
+and reported back in documents shaped by the same flags the native Senzing binary honors.
+
+Clients only use a Repository when one is attached to them.
+Without one, they return their canned "XxxResult" values.
+*/
+package repository
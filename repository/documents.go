@@ -0,0 +1,434 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type affectedEntityDocument struct {
+	EntityID int64 `json:"ENTITY_ID"`
+}
+
+type entityDocument struct {
+	ResolvedEntity  resolvedEntityDocument   `json:"RESOLVED_ENTITY"`
+	RelatedEntities *[]relatedEntityDocument `json:"RELATED_ENTITIES,omitempty"`
+}
+
+type featureDocument struct {
+	LibFeatID      int64                  `json:"LIB_FEAT_ID"`
+	FeatDesc       string                 `json:"FEAT_DESC"`
+	UsageType      string                 `json:"USAGE_TYPE,omitempty"`
+	FeatDescValues []featureValueDocument `json:"FEAT_DESC_VALUES,omitempty"`
+}
+
+type featureValueDocument struct {
+	FeatDesc string `json:"FEAT_DESC"`
+}
+
+type interestingEntitiesDocument struct {
+	Entities []interestingEntityDocument `json:"ENTITIES"`
+}
+
+type interestingEntityDocument struct {
+	EntityID int64    `json:"ENTITY_ID"`
+	Degrees  int      `json:"DEGREES"`
+	Flags    []string `json:"FLAGS"`
+}
+
+type matchingInfoDocument struct {
+	ErruleCode     string `json:"ERRULE_CODE"`
+	MatchKey       string `json:"MATCH_KEY"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+}
+
+type previewDocument struct {
+	Features     map[string][]previewFeatureDocument `json:"FEATURES,omitempty"`
+	JSONData     map[string]any                      `json:"JSON_DATA,omitempty"`
+	UnmappedData map[string]any                      `json:"UNMAPPED_DATA,omitempty"`
+}
+
+type previewFeatureDocument struct {
+	LibFeatID  int64             `json:"LIB_FEAT_ID,omitempty"` // Only for features already in the feature library.
+	FeatDesc   string            `json:"FEAT_DESC"`
+	UsageType  string            `json:"USAGE_TYPE,omitempty"`
+	Attributes map[string]string `json:"ATTRIBUTES"`
+}
+
+type recordDocument struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+	*recordMatchingInfoDocument
+	JSONData     map[string]any `json:"JSON_DATA,omitempty"`
+	UnmappedData map[string]any `json:"UNMAPPED_DATA,omitempty"`
+}
+
+type recordMatchingInfoDocument struct {
+	InternalID int64 `json:"INTERNAL_ID"`
+	matchingInfoDocument
+}
+
+type recordSummaryDocument struct {
+	DataSource  string `json:"DATA_SOURCE"`
+	RecordCount int    `json:"RECORD_COUNT"`
+}
+
+type relatedEntityDocument struct {
+	EntityID   int64  `json:"ENTITY_ID"`
+	EntityName string `json:"ENTITY_NAME,omitempty"`
+	*relatedMatchingInfoDocument
+	RecordSummary []recordSummaryDocument `json:"RECORD_SUMMARY,omitempty"`
+	Records       *[]recordDocument       `json:"RECORDS,omitempty"`
+}
+
+type relatedMatchingInfoDocument struct {
+	IsAmbiguous int `json:"IS_AMBIGUOUS"`
+	IsDisclosed int `json:"IS_DISCLOSED"`
+	matchingInfoDocument
+}
+
+type resolvedEntityDocument struct {
+	EntityID      int64                        `json:"ENTITY_ID"`
+	EntityName    string                       `json:"ENTITY_NAME,omitempty"`
+	Features      map[string][]featureDocument `json:"FEATURES,omitempty"`
+	RecordSummary []recordSummaryDocument      `json:"RECORD_SUMMARY,omitempty"`
+	Records       *[]recordDocument            `json:"RECORDS,omitempty"`
+}
+
+type withInfoDocument struct {
+	DataSource          string                      `json:"DATA_SOURCE"`
+	RecordID            string                      `json:"RECORD_ID"`
+	AffectedEntities    []affectedEntityDocument    `json:"AFFECTED_ENTITIES"`
+	InterestingEntities interestingEntitiesDocument `json:"INTERESTING_ENTITIES"`
+}
+
+// A relationship is a non-resolving decision between two entities.
+type relationship struct {
+	entityID int64
+	match    matchInfo
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Flags requesting the RECORDS of an entity.
+const includeRecords = senzing.SzEntityIncludeRecordData |
+	senzing.SzEntityIncludeRecordJSONData |
+	senzing.SzEntityIncludeRecordMatchingInfo |
+	senzing.SzEntityIncludeRecordUnmappedData
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Flags requesting the RELATED_ENTITIES of an entity, by the match level they include.
+var relationFlags = map[int64]string{
+	senzing.SzEntityIncludeDisclosedRelations:       MatchLevelDisclosed,
+	senzing.SzEntityIncludeNameOnlyRelations:        MatchLevelNameOnly,
+	senzing.SzEntityIncludePossiblyRelatedRelations: MatchLevelPossiblyRelated,
+	senzing.SzEntityIncludePossiblySameRelations:    MatchLevelPossiblySame,
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+/*
+Method entityDocument describes an entity as requested by the flags.
+
+  - senzing.SzEntityIncludeEntityName adds ENTITY_NAME.
+  - senzing.SzEntityIncludeRepresentativeFeatures or senzing.SzEntityIncludeAllFeatures adds FEATURES.
+  - senzing.SzEntityIncludeRecordSummary adds RECORD_SUMMARY.
+  - senzing.SzEntityIncludeRecordData adds RECORDS; other SzEntityIncludeRecordXxx flags add to each record.
+  - senzing.SzEntityIncludeXxxRelations flags add RELATED_ENTITIES of the corresponding match levels.
+*/
+func (repo *Repository) entityDocument(anEntity *entity, flags int64) entityDocument {
+	result := entityDocument{
+		ResolvedEntity: repo.resolvedEntityDocument(anEntity, flags),
+	}
+
+	matchLevels := relationMatchLevels(flags)
+	if len(matchLevels) > 0 {
+		relatedEntities := []relatedEntityDocument{}
+
+		for _, aRelationship := range repo.relationships(anEntity) {
+			if slices.Contains(matchLevels, aRelationship.match.matchLevel) {
+				relatedEntities = append(relatedEntities, repo.relatedEntityDocument(aRelationship, flags))
+			}
+		}
+
+		result.RelatedEntities = &relatedEntities
+	}
+
+	return result
+}
+
+func (repo *Repository) resolvedEntityDocument(anEntity *entity, flags int64) resolvedEntityDocument {
+	result := resolvedEntityDocument{
+		EntityID: anEntity.id,
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeEntityName) {
+		result.EntityName = repo.entityName(anEntity)
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRepresentativeFeatures|senzing.SzEntityIncludeAllFeatures) {
+		result.Features = repo.featuresDocument(anEntity, hasFlag(flags, senzing.SzEntityIncludeAllFeatures))
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordSummary) {
+		result.RecordSummary = repo.recordSummary(anEntity)
+	}
+
+	if hasFlag(flags, includeRecords) {
+		records := repo.recordDocuments(anEntity, flags)
+		result.Records = &records
+	}
+
+	return result
+}
+
+func (repo *Repository) relatedEntityDocument(aRelationship relationship, flags int64) relatedEntityDocument {
+	relatedEntity := repo.entities[aRelationship.entityID]
+	result := relatedEntityDocument{
+		EntityID: relatedEntity.id,
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRelatedEntityName) {
+		result.EntityName = repo.entityName(relatedEntity)
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRelatedMatchingInfo) {
+		result.relatedMatchingInfoDocument = &relatedMatchingInfoDocument{
+			IsAmbiguous:          boolToInt(repo.isAmbiguous(relatedEntity)),
+			IsDisclosed:          boolToInt(aRelationship.match.matchLevel == MatchLevelDisclosed),
+			matchingInfoDocument: aRelationship.match.document(),
+		}
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRelatedRecordSummary) {
+		result.RecordSummary = repo.recordSummary(relatedEntity)
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRelatedRecordData) {
+		records := repo.recordDocuments(relatedEntity, senzing.SzNoFlags)
+		result.Records = &records
+	}
+
+	return result
+}
+
+func (repo *Repository) recordDocument(aRecord *record, flags int64) recordDocument {
+	result := recordDocument{
+		DataSource: aRecord.dataSource,
+		RecordID:   aRecord.recordID,
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordMatchingInfo) {
+		result.recordMatchingInfoDocument = &recordMatchingInfoDocument{
+			InternalID:           aRecord.internalID,
+			matchingInfoDocument: aRecord.match.document(),
+		}
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordJSONData) {
+		result.JSONData = aRecord.jsonData
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordUnmappedData) {
+		result.UnmappedData = aRecord.unmapped
+	}
+
+	return result
+}
+
+func (repo *Repository) recordDocuments(anEntity *entity, flags int64) []recordDocument {
+	result := make([]recordDocument, 0, len(anEntity.recordKeys))
+	for _, key := range anEntity.recordKeys {
+		result = append(result, repo.recordDocument(repo.records[key], flags))
+	}
+
+	return result
+}
+
+/*
+Method entityName returns the name of an entity: the first primary name, otherwise the first name.
+*/
+func (repo *Repository) entityName(anEntity *entity) string {
+	result := ""
+
+	for _, key := range anEntity.recordKeys {
+		for _, aFeature := range repo.records[key].features {
+			if aFeature.featureType != "NAME" {
+				continue
+			}
+
+			if aFeature.usageType == "PRIMARY" {
+				return aFeature.description()
+			}
+
+			if len(result) == 0 {
+				result = aFeature.description()
+			}
+		}
+	}
+
+	return result
+}
+
+/*
+Method featuresDocument lists the distinct features of an entity by feature type.
+With allValues, each feature also lists the values it was built from.
+*/
+func (repo *Repository) featuresDocument(anEntity *entity, allValues bool) map[string][]featureDocument {
+	result := map[string][]featureDocument{}
+	seen := map[string]int{}
+
+	for _, key := range anEntity.recordKeys {
+		for _, aFeature := range repo.records[key].features {
+			seenKey := aFeature.libraryKey()
+			description := aFeature.description()
+
+			index, found := seen[seenKey]
+			if !found {
+				index = len(result[aFeature.featureType])
+				seen[seenKey] = index
+				result[aFeature.featureType] = append(result[aFeature.featureType], featureDocument{
+					LibFeatID: repo.libraryFeatureID(aFeature),
+					FeatDesc:  description,
+					UsageType: aFeature.usageType,
+				})
+			}
+
+			if allValues {
+				values := &result[aFeature.featureType][index].FeatDescValues
+				if !slices.Contains(*values, featureValueDocument{FeatDesc: description}) {
+					*values = append(*values, featureValueDocument{FeatDesc: description})
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func (repo *Repository) recordSummary(anEntity *entity) []recordSummaryDocument {
+	result := []recordSummaryDocument{}
+	counts := map[string]int{}
+
+	for _, key := range anEntity.recordKeys {
+		counts[key.dataSource]++
+	}
+
+	for _, dataSource := range sortedKeys(counts) {
+		result = append(result, recordSummaryDocument{DataSource: dataSource, RecordCount: counts[dataSource]})
+	}
+
+	return result
+}
+
+/*
+Method relationships lists the entities related to an entity by a rule that does not resolve
+or by a disclosed relationship, ordered by entity ID.
+*/
+func (repo *Repository) relationships(anEntity *entity) []relationship {
+	result := []relationship{}
+
+	for _, entityID := range repo.entityIDs() {
+		if entityID == anEntity.id {
+			continue
+		}
+
+		decision, found := repo.compareEntities(anEntity, repo.entities[entityID])
+
+		switch {
+		case found && decision.matchLevel != MatchLevelResolved:
+			result = append(result, relationship{entityID: entityID, match: decision})
+		case repo.isDisclosed(anEntity, repo.entities[entityID]):
+			result = append(result, relationship{entityID: entityID, match: disclosedMatchInfo})
+		}
+	}
+
+	return result
+}
+
+func (aMatchInfo matchInfo) document() matchingInfoDocument {
+	return matchingInfoDocument{
+		ErruleCode:     aMatchInfo.errRuleCode,
+		MatchKey:       aMatchInfo.matchKey,
+		MatchLevelCode: aMatchInfo.matchLevel,
+	}
+}
+
+/*
+Method withInfo returns the "with info" document of a write operation.
+
+Input
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - affected: The IDs of the entities the operation changed. Duplicates are ignored.
+  - flags: Flags used to control information returned.
+
+Output
+  - The document if flags include senzing.SzWithInfo; otherwise an empty string.
+    INTERESTING_ENTITIES lists the entities the interest rules find near the affected entities.
+*/
+func (repo *Repository) withInfo(
+	dataSourceCode string,
+	recordID string,
+	affected []int64,
+	flags int64,
+) (string, error) {
+	if !hasFlag(flags, senzing.SzWithInfo) {
+		return "", nil
+	}
+
+	result := withInfoDocument{
+		DataSource:          dataSourceCode,
+		RecordID:            recordID,
+		AffectedEntities:    []affectedEntityDocument{},
+		InterestingEntities: repo.interestingEntitiesDocument(affected),
+	}
+
+	slices.Sort(affected)
+
+	for _, entityID := range slices.Compact(affected) {
+		result.AffectedEntities = append(result.AffectedEntities, affectedEntityDocument{EntityID: entityID})
+	}
+
+	return marshal(result)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+// Report whether any of the bits in flag are set in flags.
+func hasFlag(flags int64, flag int64) bool {
+	return flags&flag != 0
+}
+
+func relationMatchLevels(flags int64) []string {
+	result := []string{}
+
+	for flag, matchLevel := range relationFlags {
+		if hasFlag(flags, flag) {
+			result = append(result, matchLevel)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// An export is a snapshot of exported lines, handed out one line per FetchNext call.
+type export struct {
+	lines    []string
+	position int
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// All columns available for CSV export, in output order.
+var csvColumnsAll = []string{
+	"RESOLVED_ENTITY_ID",
+	"RESOLVED_ENTITY_NAME",
+	"RELATED_ENTITY_ID",
+	"MATCH_LEVEL",
+	"MATCH_LEVEL_CODE",
+	"MATCH_KEY",
+	"IS_DISCLOSED",
+	"IS_AMBIGUOUS",
+	"DATA_SOURCE",
+	"RECORD_ID",
+	"ERRULE_CODE",
+	"JSON_DATA",
+}
+
+// Columns exported when an empty column list is requested.
+var csvColumnsStandard = []string{
+	"RESOLVED_ENTITY_ID",
+	"RELATED_ENTITY_ID",
+	"MATCH_LEVEL",
+	"MATCH_KEY",
+	"DATA_SOURCE",
+	"RECORD_ID",
+}
+
+// Flags selecting entities for export by the match levels of their relationships.
+var exportRelationFlags = map[int64]string{
+	senzing.SzExportIncludeDisclosed:       MatchLevelDisclosed,
+	senzing.SzExportIncludeNameOnly:        MatchLevelNameOnly,
+	senzing.SzExportIncludePossiblyRelated: MatchLevelPossiblyRelated,
+	senzing.SzExportIncludePossiblySame:    MatchLevelPossiblySame,
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CloseExportReport releases an export handle.
+
+Input
+  - ctx: A context to control lifecycle.
+  - exportHandle: A handle created by [Repository.ExportJSONEntityReport] or [Repository.ExportCsvEntityReport].
+*/
+func (repo *Repository) CloseExportReport(ctx context.Context, exportHandle uintptr) error {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("closeExportReport", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return err
+	}
+
+	if _, found := repo.exports[exportHandle]; !found {
+		return newError(3103, "Invalid Export Handle [%d]", exportHandle)
+	}
+
+	delete(repo.exports, exportHandle)
+
+	return nil
+}
+
+/*
+Method ExportCsvEntityReport snapshots the exported entities as CSV lines.
+
+Input
+  - ctx: A context to control lifecycle.
+  - csvColumnList: Use `*` to request all columns, an empty string to request "standard" columns,
+    or a comma-separated list of column names for customized columns.
+  - flags: Flags used to select entities and relationships.
+
+Output
+  - A handle for [Repository.FetchNext]. The first line fetched is the CSV header.
+*/
+func (repo *Repository) ExportCsvEntityReport(ctx context.Context, csvColumnList string, flags int64) (uintptr, error) {
+	_ = ctx
+
+	columns, err := parseCsvColumnList(csvColumnList)
+	if err != nil {
+		return 0, err
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("exportCsvEntityReport", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return 0, err
+	}
+
+	lines := []string{csvLine(columns)}
+
+	for _, entityID := range repo.exportedEntityIDs(flags) {
+		for _, row := range repo.csvRows(repo.entities[entityID], flags) {
+			values := make([]string, 0, len(columns))
+			for _, column := range columns {
+				values = append(values, row[column])
+			}
+
+			lines = append(lines, csvLine(values))
+		}
+	}
+
+	return repo.newExport(lines), nil
+}
+
+/*
+Method ExportJSONEntityReport snapshots the exported entities as JSON lines.
+
+Input
+  - ctx: A context to control lifecycle.
+  - flags: Flags used to select entities and to shape each entity document.
+
+Output
+  - A handle for [Repository.FetchNext].
+*/
+func (repo *Repository) ExportJSONEntityReport(ctx context.Context, flags int64) (uintptr, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("exportJSONEntityReport", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return 0, err
+	}
+
+	lines := []string{}
+
+	for _, entityID := range repo.exportedEntityIDs(flags) {
+		line, err := marshal(repo.entityDocument(repo.entities[entityID], flags))
+		if err != nil {
+			return 0, err
+		}
+
+		lines = append(lines, line+jsonLineEnd)
+	}
+
+	return repo.newExport(lines), nil
+}
+
+/*
+Method FetchNext returns the next line of an export.
+
+Input
+  - ctx: A context to control lifecycle.
+  - exportHandle: A handle created by [Repository.ExportJSONEntityReport] or [Repository.ExportCsvEntityReport].
+
+Output
+  - The next line. An empty string signifies end of data.
+*/
+func (repo *Repository) FetchNext(ctx context.Context, exportHandle uintptr) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("fetchNext", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	anExport, found := repo.exports[exportHandle]
+	if !found {
+		return "", newError(3103, "Invalid Export Handle [%d]", exportHandle)
+	}
+
+	if anExport.position >= len(anExport.lines) {
+		return "", nil
+	}
+
+	anExport.position++
+
+	return anExport.lines[anExport.position-1], nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (repo *Repository) newExport(lines []string) uintptr {
+	repo.lastExportHandle++
+	repo.exports[repo.lastExportHandle] = &export{lines: lines}
+
+	return repo.lastExportHandle
+}
+
+/*
+Method exportedEntityIDs selects entities by the SzExportIncludeXxx flags.
+Entities are selected by their size or by the match levels of their relationships.
+*/
+func (repo *Repository) exportedEntityIDs(flags int64) []int64 {
+	result := []int64{}
+	matchLevels := []string{}
+
+	for flag, matchLevel := range exportRelationFlags {
+		if hasFlag(flags, flag) {
+			matchLevels = append(matchLevels, matchLevel)
+		}
+	}
+
+	for _, entityID := range repo.entityIDs() {
+		anEntity := repo.entities[entityID]
+		isSingle := len(anEntity.recordKeys) == 1
+
+		switch {
+		case isSingle && hasFlag(flags, senzing.SzExportIncludeSingleRecordEntities),
+			!isSingle && hasFlag(flags, senzing.SzExportIncludeMultiRecordEntities):
+			result = append(result, entityID)
+		case len(matchLevels) > 0:
+			for _, aRelationship := range repo.relationships(anEntity) {
+				if slices.Contains(matchLevels, aRelationship.match.matchLevel) {
+					result = append(result, entityID)
+
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+/*
+Method csvRows lists one row per record of an entity,
+followed by one row per record of each related entity requested by the flags.
+*/
+func (repo *Repository) csvRows(anEntity *entity, flags int64) []map[string]string {
+	result := []map[string]string{}
+	entityName := repo.entityName(anEntity)
+
+	for _, key := range anEntity.recordKeys {
+		aRecord := repo.records[key]
+		result = append(result, csvRow(anEntity.id, entityName, 0, false, aRecord, aRecord.match))
+	}
+
+	matchLevels := relationMatchLevels(flags)
+
+	for _, aRelationship := range repo.relationships(anEntity) {
+		if !slices.Contains(matchLevels, aRelationship.match.matchLevel) {
+			continue
+		}
+
+		relatedEntity := repo.entities[aRelationship.entityID]
+		isAmbiguous := repo.isAmbiguous(relatedEntity)
+
+		for _, key := range relatedEntity.recordKeys {
+			result = append(
+				result,
+				csvRow(anEntity.id, entityName, relatedEntity.id, isAmbiguous, repo.records[key], aRelationship.match),
+			)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func csvLine(values []string) string {
+	var buffer bytes.Buffer
+
+	writer := csv.NewWriter(&buffer)
+	_ = writer.Write(values)
+	writer.Flush()
+
+	return buffer.String()
+}
+
+func csvRow(
+	entityID int64,
+	entityName string,
+	relatedEntityID int64,
+	isAmbiguous bool,
+	aRecord *record,
+	match matchInfo,
+) map[string]string {
+	jsonData, _ := json.Marshal(aRecord.jsonData)
+	matchLevel := 0
+
+	if len(match.matchLevel) > 0 {
+		matchLevel = matchLevelRank(match.matchLevel)
+	}
+
+	return map[string]string{
+		"RESOLVED_ENTITY_ID":   strconv.FormatInt(entityID, 10),
+		"RESOLVED_ENTITY_NAME": entityName,
+		"RELATED_ENTITY_ID":    strconv.FormatInt(relatedEntityID, 10),
+		"MATCH_LEVEL":          strconv.Itoa(matchLevel),
+		"MATCH_LEVEL_CODE":     match.matchLevel,
+		"MATCH_KEY":            match.matchKey,
+		"IS_DISCLOSED":         strconv.Itoa(boolToInt(match.matchLevel == MatchLevelDisclosed)),
+		"IS_AMBIGUOUS":         strconv.Itoa(boolToInt(isAmbiguous)),
+		"DATA_SOURCE":          aRecord.dataSource,
+		"RECORD_ID":            aRecord.recordID,
+		"ERRULE_CODE":          match.errRuleCode,
+		"JSON_DATA":            string(jsonData),
+	}
+}
+
+func parseCsvColumnList(csvColumnList string) ([]string, error) {
+	switch strings.TrimSpace(csvColumnList) {
+	case "":
+		return csvColumnsStandard, nil
+	case "*":
+		return csvColumnsAll, nil
+	}
+
+	result := []string{}
+
+	for _, column := range strings.Split(csvColumnList, ",") {
+		column = strings.ToUpper(strings.TrimSpace(column))
+		if !slices.Contains(csvColumnsAll, column) {
+			return nil, newError(3131, "Invalid column [%s] requested for CSV export.", column)
+		}
+
+		result = append(result, column)
+	}
+
+	return result, nil
+}
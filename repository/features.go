@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An attribute maps a well-known record attribute to a feature type and feature element.
+type attribute struct {
+	element     string
+	featureType string
+	name        string
+}
+
+// An element is one part of a feature, e.g. the SUR_NAME of a NAME.
+type element struct {
+	code  string
+	value string
+}
+
+// A feature is one observed characteristic of a record, e.g. a NAME or an ADDRESS.
+type feature struct {
+	attributes  map[string]string // Original attribute name to value.
+	elements    []element
+	featureType string
+	usageType   string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Well-known attributes, in the order their elements are described.
+var attributes = []attribute{
+	{name: "NAME_ORG", featureType: "NAME", element: "ORG_NAME"},
+	{name: "NAME_FULL", featureType: "NAME", element: "FULL_NAME"},
+	{name: "NAME_PREFIX", featureType: "NAME", element: "PRE"},
+	{name: "NAME_FIRST", featureType: "NAME", element: "GIVEN_NAME"},
+	{name: "NAME_MIDDLE", featureType: "NAME", element: "MIDDLE_NAME"},
+	{name: "NAME_LAST", featureType: "NAME", element: "SUR_NAME"},
+	{name: "NAME_SUFFIX", featureType: "NAME", element: "SUFFIX"},
+	{name: "DATE_OF_BIRTH", featureType: "DOB", element: "DATE"},
+	{name: "GENDER", featureType: "GENDER", element: "GENDER"},
+	{name: "ADDR_FULL", featureType: "ADDRESS", element: "FULL_ADDR"},
+	{name: "ADDR_LINE1", featureType: "ADDRESS", element: "ADDR1"},
+	{name: "ADDR_LINE2", featureType: "ADDRESS", element: "ADDR2"},
+	{name: "ADDR_LINE3", featureType: "ADDRESS", element: "ADDR3"},
+	{name: "ADDR_CITY", featureType: "ADDRESS", element: "CITY"},
+	{name: "ADDR_STATE", featureType: "ADDRESS", element: "STATE"},
+	{name: "ADDR_POSTAL_CODE", featureType: "ADDRESS", element: "POSTAL_CODE"},
+	{name: "ADDR_COUNTRY", featureType: "ADDRESS", element: "COUNTRY"},
+	{name: "PHONE_NUMBER", featureType: "PHONE", element: "PHONE_NUM"},
+	{name: "EMAIL_ADDRESS", featureType: "EMAIL", element: "ADDR"},
+	{name: "WEBSITE_ADDRESS", featureType: "WEBSITE", element: "ADDR"},
+	{name: "SSN_NUMBER", featureType: "SSN", element: "ID_NUM"},
+	{name: "DRIVERS_LICENSE_NUMBER", featureType: "DRLIC", element: "ID_NUM"},
+	{name: "DRIVERS_LICENSE_STATE", featureType: "DRLIC", element: "STATE"},
+	{name: "PASSPORT_NUMBER", featureType: "PASSPORT", element: "ID_NUM"},
+	{name: "PASSPORT_COUNTRY", featureType: "PASSPORT", element: "COUNTRY"},
+	{name: "NATIONAL_ID_NUMBER", featureType: "NATIONAL_ID", element: "ID_NUM"},
+	{name: "NATIONAL_ID_COUNTRY", featureType: "NATIONAL_ID", element: "COUNTRY"},
+	{name: "TAX_ID_NUMBER", featureType: "TAX_ID", element: "ID_NUM"},
+	{name: "TAX_ID_COUNTRY", featureType: "TAX_ID", element: "COUNTRY"},
+}
+
+// Feature types in the order they appear in match keys and FEATURES documents.
+var featureTypes = []string{
+	"NAME", "DOB", "GENDER", "ADDRESS", "PHONE", "EMAIL", "WEBSITE",
+	"SSN", "DRLIC", "PASSPORT", "NATIONAL_ID", "TAX_ID",
+}
+
+// Attributes naming the usage type of a feature, e.g. ADDR_TYPE = "HOME".
+var usageAttributes = map[string]string{
+	"ADDR_TYPE":  "ADDRESS",
+	"NAME_TYPE":  "NAME",
+	"PHONE_TYPE": "PHONE",
+}
+
+// Keys that identify a record rather than describe it.
+var reservedAttributes = map[string]bool{
+	"DATA_SOURCE": true,
+	"RECORD_ID":   true,
+	"RECORD_TYPE": true,
+}
+
+// Date layouts understood when comparing dates of birth.
+var dateLayouts = []string{
+	"1/2/2006", "1/2/06", "2006-01-02", "2006/01/02", "Jan 2 2006", "January 2 2006", "2 Jan 2006", "20060102",
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Function extractFeatures maps the attributes of a record to features.
+
+Attributes may be prefixed, as in PRIMARY_NAME_FIRST, or nested in lists of objects, as in NAMES.
+Attributes sharing a prefix within the same object describe the same feature.
+
+Input
+  - jsonData: The parsed record definition.
+
+Output
+  - The features of the record, ordered by feature type.
+  - The attributes that do not describe a feature.
+*/
+func extractFeatures(jsonData map[string]any) ([]feature, map[string]any) {
+	builder := &featureBuilder{
+		groups:   map[string]*feature{},
+		unmapped: map[string]any{},
+	}
+	builder.walk(jsonData, "")
+
+	return builder.features(), builder.unmapped
+}
+
+type featureBuilder struct {
+	groups   map[string]*feature
+	order    []string
+	unmapped map[string]any
+}
+
+func (builder *featureBuilder) walk(jsonData map[string]any, groupPrefix string) {
+	for _, key := range sortedKeys(jsonData) {
+		value := jsonData[key]
+
+		if list, isList := value.([]any); isList {
+			builder.walkList(key, list, groupPrefix)
+
+			continue
+		}
+
+		text := strings.TrimSpace(stringify(value))
+
+		switch {
+		case reservedAttributes[key] && len(groupPrefix) == 0:
+		case len(text) == 0:
+		case builder.addUsage(key, text, groupPrefix):
+		case builder.addAttribute(key, text, groupPrefix):
+		case len(groupPrefix) == 0:
+			builder.unmapped[key] = value
+		}
+	}
+}
+
+func (builder *featureBuilder) walkList(key string, list []any, groupPrefix string) {
+	mapped := false
+
+	for index, item := range list {
+		if object, isObject := item.(map[string]any); isObject {
+			builder.walk(object, fmt.Sprintf("%s%s[%d].", groupPrefix, key, index))
+
+			mapped = true
+		}
+	}
+
+	if !mapped && len(groupPrefix) == 0 {
+		builder.unmapped[key] = list
+	}
+}
+
+func (builder *featureBuilder) addAttribute(key string, value string, groupPrefix string) bool {
+	for _, candidate := range attributes {
+		prefix, found := attributePrefix(key, candidate.name)
+		if !found {
+			continue
+		}
+
+		aFeature := builder.group(groupPrefix+prefix, candidate.featureType)
+		aFeature.attributes[key] = value
+		aFeature.elements = append(aFeature.elements, element{code: candidate.element, value: value})
+
+		if len(aFeature.usageType) == 0 {
+			aFeature.usageType = strings.Trim(prefix, "_")
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (builder *featureBuilder) addUsage(key string, value string, groupPrefix string) bool {
+	for usageAttribute, featureType := range usageAttributes {
+		prefix, found := attributePrefix(key, usageAttribute)
+		if !found {
+			continue
+		}
+
+		aFeature := builder.group(groupPrefix+prefix, featureType)
+		aFeature.usageType = strings.ToUpper(value)
+
+		return true
+	}
+
+	return false
+}
+
+func (builder *featureBuilder) features() []feature {
+	result := []feature{}
+
+	for _, groupKey := range builder.order {
+		aFeature := builder.groups[groupKey]
+		if len(aFeature.elements) == 0 {
+			continue
+		}
+
+		sort.SliceStable(aFeature.elements, func(i, j int) bool {
+			return elementRank(aFeature.featureType, aFeature.elements[i].code) <
+				elementRank(aFeature.featureType, aFeature.elements[j].code)
+		})
+
+		result = append(result, *aFeature)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return featureTypeRank(result[i].featureType) < featureTypeRank(result[j].featureType)
+	})
+
+	return result
+}
+
+func (builder *featureBuilder) group(prefix string, featureType string) *feature {
+	groupKey := prefix + "|" + featureType
+
+	aFeature, found := builder.groups[groupKey]
+	if !found {
+		aFeature = &feature{
+			attributes:  map[string]string{},
+			featureType: featureType,
+		}
+		builder.groups[groupKey] = aFeature
+		builder.order = append(builder.order, groupKey)
+	}
+
+	return aFeature
+}
+
+func attributePrefix(key string, attributeName string) (string, bool) {
+	if key == attributeName {
+		return "", true
+	}
+
+	if strings.HasSuffix(key, "_"+attributeName) {
+		return strings.TrimSuffix(key, attributeName), true
+	}
+
+	return "", false
+}
+
+func elementRank(featureType string, code string) int {
+	for index, candidate := range attributes {
+		if candidate.featureType == featureType && candidate.element == code {
+			return index
+		}
+	}
+
+	return len(attributes)
+}
+
+func featureTypeRank(featureType string) int {
+	for index, candidate := range featureTypes {
+		if candidate == featureType {
+			return index
+		}
+	}
+
+	return len(featureTypes)
+}
+
+func sortedKeys[V any](aMap map[string]V) []string {
+	result := make([]string, 0, len(aMap))
+	for key := range aMap {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func stringify(value any) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	default:
+		return fmt.Sprint(typedValue)
+	}
+}
+
+// --- Feature descriptions and comparison -------------------------------------
+
+/*
+Method description returns the FEAT_DESC of a feature.
+*/
+func (aFeature feature) description() string {
+	values := make([]string, 0, len(aFeature.elements))
+
+	for _, anElement := range aFeature.elements {
+		switch anElement.code {
+		case "FULL_NAME", "ORG_NAME", "FULL_ADDR":
+			return anElement.value
+		}
+
+		values = append(values, anElement.value)
+	}
+
+	return strings.Join(values, " ")
+}
+
+/*
+Method libraryKey returns the key of a feature in the feature library.
+Features of the same type with the same normalized value share a key.
+*/
+func (aFeature feature) libraryKey() string {
+	return aFeature.featureType + "|" + aFeature.normalized()
+}
+
+/*
+Method normalized returns the value used to compare features of the same type.
+*/
+func (aFeature feature) normalized() string {
+	description := aFeature.description()
+
+	switch aFeature.featureType {
+	case "DOB":
+		return normalizeDate(description)
+	case "PHONE", "SSN", "DRLIC", "PASSPORT", "NATIONAL_ID", "TAX_ID":
+		return normalizeAlphanumeric(description, false)
+	default:
+		return normalizeAlphanumeric(description, true)
+	}
+}
+
+/*
+Method agrees reports whether two features of the same type describe the same thing.
+
+Names also agree when their surnames match and their given names share an initial.
+*/
+func (aFeature feature) agrees(other feature) bool {
+	if aFeature.featureType != other.featureType {
+		return false
+	}
+
+	normalized := aFeature.normalized()
+	otherNormalized := other.normalized()
+
+	if normalized == otherNormalized {
+		return len(normalized) > 0
+	}
+
+	if aFeature.featureType != "NAME" {
+		return false
+	}
+
+	tokens := strings.Fields(normalized)
+	otherTokens := strings.Fields(otherNormalized)
+
+	if len(tokens) < 2 || len(otherTokens) < 2 {
+		return false
+	}
+
+	return tokens[len(tokens)-1] == otherTokens[len(otherTokens)-1] && tokens[0][0] == otherTokens[0][0]
+}
+
+func normalizeAlphanumeric(value string, keepSpaces bool) string {
+	var builder strings.Builder
+
+	for _, aRune := range strings.ToUpper(value) {
+		switch {
+		case unicode.IsLetter(aRune), unicode.IsDigit(aRune):
+			builder.WriteRune(aRune)
+		case keepSpaces:
+			builder.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+func normalizeDate(value string) string {
+	cleaned := strings.Join(strings.Fields(strings.ReplaceAll(value, ",", " ")), " ")
+
+	for _, layout := range dateLayouts {
+		parsed, err := time.Parse(layout, cleaned)
+		if err == nil {
+			return parsed.Format(time.DateOnly)
+		}
+	}
+
+	return normalizeAlphanumeric(value, true)
+}
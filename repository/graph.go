@@ -0,0 +1,788 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A disclosure is a relationship between two records declared by the caller rather than found by the resolver.
+type disclosure struct {
+	recordKey1 recordKey
+	recordKey2 recordKey
+}
+
+type entityLinkDocument struct {
+	MinEntityID int64 `json:"MIN_ENTITY_ID"`
+	MaxEntityID int64 `json:"MAX_ENTITY_ID"`
+	relatedMatchingInfoDocument
+}
+
+type entityPathDocument struct {
+	StartEntityID int64   `json:"START_ENTITY_ID"`
+	EndEntityID   int64   `json:"END_ENTITY_ID"`
+	Entities      []int64 `json:"ENTITIES"`
+}
+
+type networkDocument struct {
+	EntityPaths        []entityPathDocument  `json:"ENTITY_PATHS"`
+	EntityNetworkLinks *[]entityLinkDocument `json:"ENTITY_NETWORK_LINKS,omitempty"`
+	Entities           []entityDocument      `json:"ENTITIES"`
+}
+
+type pathDocument struct {
+	EntityPaths     []entityPathDocument  `json:"ENTITY_PATHS"`
+	EntityPathLinks *[]entityLinkDocument `json:"ENTITY_PATH_LINKS,omitempty"`
+	Entities        []entityDocument      `json:"ENTITIES"`
+}
+
+// A graph caches the relationships of entities while a path or network is searched.
+type graph struct {
+	links map[int64][]relationship
+	repo  *Repository
+}
+
+// A pathSearch constrains the paths a graph search may return.
+type pathSearch struct {
+	avoid               map[int64]bool
+	maxDegrees          int64
+	requiredDataSources []string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The decision reported for a disclosed relationship.
+var disclosedMatchInfo = matchInfo{
+	errRuleCode: "DISCLOSED",
+	matchKey:    "+REL_POINTER",
+	matchLevel:  MatchLevelDisclosed,
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method DiscloseRelationship declares that two records are related.
+
+Unless the records resolve to the same entity, their entities are related with
+MATCH_LEVEL_CODE "DISCLOSED".
+The records need not have been added yet.
+
+Input
+  - dataSourceCode1: Identifies the provenance of the first record.
+  - recordID1: The unique identifier of the first record within its data source.
+  - dataSourceCode2: Identifies the provenance of the second record.
+  - recordID2: The unique identifier of the second record within its data source.
+*/
+func (repo *Repository) DiscloseRelationship(
+	dataSourceCode1 string,
+	recordID1 string,
+	dataSourceCode2 string,
+	recordID2 string,
+) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+
+	repo.disclosures = append(repo.disclosures, disclosure{
+		recordKey1: recordKey{dataSource: dataSourceCode1, recordID: recordID1},
+		recordKey2: recordKey{dataSource: dataSourceCode2, recordID: recordID2},
+	})
+}
+
+/*
+Method FindNetworkByEntityID finds the paths among a set of entities and the entities around them.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityIDs: A JSON document listing entities. Example: `{"ENTITIES": [{"ENTITY_ID": 1}, {"ENTITY_ID": 2}]}`
+  - maxDegrees: The maximum number of degrees for paths between entities.
+  - buildOutDegrees: The number of degrees of relationships to show around each entity on the network.
+  - buildOutMaxEntities: The maximum number of entities to add while building out.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags.
+*/
+func (repo *Repository) FindNetworkByEntityID(
+	ctx context.Context,
+	entityIDs string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("findNetworkByEntityID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	parsedEntityIDs, err := parseEntityIDs(entityIDs)
+	if err != nil {
+		return "", err
+	}
+
+	requested, err := repo.getEntities(parsedEntityIDs)
+	if err != nil {
+		return "", err
+	}
+
+	return repo.findNetwork(requested, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
+}
+
+/*
+Method FindNetworkByRecordID finds the paths among the entities of a set of records and the entities around them.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordKeys: A JSON document listing records.
+    Example: `{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}`
+  - maxDegrees: The maximum number of degrees for paths between entities.
+  - buildOutDegrees: The number of degrees of relationships to show around each entity on the network.
+  - buildOutMaxEntities: The maximum number of entities to add while building out.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags.
+*/
+func (repo *Repository) FindNetworkByRecordID(
+	ctx context.Context,
+	recordKeys string,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("findNetworkByRecordID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	parsedRecordKeys, err := parseRecordKeys(recordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	requested, err := repo.getEntitiesOfRecords(parsedRecordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	return repo.findNetwork(requested, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
+}
+
+/*
+Method FindPathByEntityID finds the shortest relationship path between two entities.
+
+Entities in avoidEntityIDs are used only if no other path exists,
+or never if flags include senzing.SzFindPathStrictAvoid.
+
+Input
+  - ctx: A context to control lifecycle.
+  - startEntityID: The entity ID of the first entity.
+  - endEntityID: The entity ID of the second entity.
+  - maxDegrees: The maximum number of degrees for the path.
+  - avoidEntityIDs: A JSON document listing entities to avoid. Example: `{"ENTITIES": [{"ENTITY_ID": 1}]}`
+  - requiredDataSources: A JSON document listing data sources of which at least one entity on the path
+    must have a record. Example: `{"DATA_SOURCES": ["CUSTOMERS"]}`
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags. If no path exists, the path lists no entities.
+*/
+func (repo *Repository) FindPathByEntityID(
+	ctx context.Context,
+	startEntityID int64,
+	endEntityID int64,
+	maxDegrees int64,
+	avoidEntityIDs string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("findPathByEntityID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	avoid, err := parseEntityIDs(avoidEntityIDs)
+	if err != nil {
+		return "", err
+	}
+
+	search, err := newPathSearch(maxDegrees, requiredDataSources)
+	if err != nil {
+		return "", err
+	}
+
+	endpoints, err := repo.getEntities([]int64{startEntityID, endEntityID})
+	if err != nil {
+		return "", err
+	}
+
+	for _, entityID := range avoid {
+		search.avoid[entityID] = true
+	}
+
+	return repo.findPath(endpoints[0], endpoints[1], search, flags)
+}
+
+/*
+Method FindPathByRecordID finds the shortest relationship path between the entities of two records.
+
+Entities containing records in avoidRecordKeys are used only if no other path exists,
+or never if flags include senzing.SzFindPathStrictAvoid.
+
+Input
+  - ctx: A context to control lifecycle.
+  - startDataSourceCode: Identifies the provenance of the record for the starting entity of the search path.
+  - startRecordID: The unique identifier within the records of the same data source for the starting entity.
+  - endDataSourceCode: Identifies the provenance of the record for the ending entity of the search path.
+  - endRecordID: The unique identifier within the records of the same data source for the ending entity.
+  - maxDegrees: The maximum number of degrees for the path.
+  - avoidRecordKeys: A JSON document listing records whose entities are avoided.
+    Example: `{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}`
+  - requiredDataSources: A JSON document listing data sources of which at least one entity on the path
+    must have a record. Example: `{"DATA_SOURCES": ["CUSTOMERS"]}`
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags. If no path exists, the path lists no entities.
+*/
+func (repo *Repository) FindPathByRecordID(
+	ctx context.Context,
+	startDataSourceCode string,
+	startRecordID string,
+	endDataSourceCode string,
+	endRecordID string,
+	maxDegrees int64,
+	avoidRecordKeys string,
+	requiredDataSources string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("findPathByRecordID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	avoid, err := parseRecordKeys(avoidRecordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	search, err := newPathSearch(maxDegrees, requiredDataSources)
+	if err != nil {
+		return "", err
+	}
+
+	endpoints, err := repo.getEntitiesOfRecords([]recordKey{
+		{dataSource: startDataSourceCode, recordID: startRecordID},
+		{dataSource: endDataSourceCode, recordID: endRecordID},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range avoid {
+		if aRecord, found := repo.records[key]; found {
+			search.avoid[aRecord.entityID] = true
+		}
+	}
+
+	return repo.findPath(endpoints[0], endpoints[1], search, flags)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (repo *Repository) findNetwork(
+	requested []*entity,
+	maxDegrees int64,
+	buildOutDegrees int64,
+	buildOutMaxEntities int64,
+	flags int64,
+) (string, error) {
+	aGraph := repo.newGraph()
+	search := pathSearch{maxDegrees: maxDegrees}
+	result := networkDocument{
+		EntityPaths: []entityPathDocument{},
+		Entities:    []entityDocument{},
+	}
+	members := []int64{}
+
+	for index, start := range requested {
+		members = append(members, start.id)
+
+		for _, end := range requested[index+1:] {
+			path := aGraph.shortestPath(start.id, end.id, search)
+			members = append(members, path...)
+			result.EntityPaths = append(result.EntityPaths, entityPathDocument{
+				StartEntityID: start.id,
+				EndEntityID:   end.id,
+				Entities:      path,
+			})
+		}
+	}
+
+	members = aGraph.buildOut(members, buildOutDegrees, buildOutMaxEntities)
+
+	for _, entityID := range members {
+		result.Entities = append(result.Entities, repo.entityDocument(repo.entities[entityID], flags))
+	}
+
+	if hasFlag(flags, senzing.SzFindNetworkIncludeMatchingInfo) {
+		links := aGraph.linksAmong(members)
+		result.EntityNetworkLinks = &links
+	}
+
+	return marshal(result)
+}
+
+func (repo *Repository) findPath(start *entity, end *entity, search pathSearch, flags int64) (string, error) {
+	aGraph := repo.newGraph()
+
+	path := aGraph.shortestPath(start.id, end.id, search)
+	if len(path) == 0 && len(search.avoid) > 0 && !hasFlag(flags, senzing.SzFindPathStrictAvoid) {
+		search.avoid = nil
+		path = aGraph.shortestPath(start.id, end.id, search)
+	}
+
+	result := pathDocument{
+		EntityPaths: []entityPathDocument{{
+			StartEntityID: start.id,
+			EndEntityID:   end.id,
+			Entities:      path,
+		}},
+		Entities: []entityDocument{},
+	}
+
+	members := path
+	if len(members) == 0 {
+		members = []int64{start.id, end.id}
+	}
+
+	for _, entityID := range slices.Compact(members) {
+		result.Entities = append(result.Entities, repo.entityDocument(repo.entities[entityID], flags))
+	}
+
+	if hasFlag(flags, senzing.SzFindPathIncludeMatchingInfo) {
+		links := aGraph.linksAlong(path)
+		result.EntityPathLinks = &links
+	}
+
+	return marshal(result)
+}
+
+// Return the entities with the given IDs, in order.
+func (repo *Repository) getEntities(entityIDs []int64) ([]*entity, error) {
+	result := make([]*entity, 0, len(entityIDs))
+
+	for _, entityID := range entityIDs {
+		anEntity, err := repo.getEntity(entityID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, anEntity)
+	}
+
+	return result, nil
+}
+
+// Return the entities containing the given records, in order.
+func (repo *Repository) getEntitiesOfRecords(keys []recordKey) ([]*entity, error) {
+	result := make([]*entity, 0, len(keys))
+
+	for _, key := range keys {
+		aRecord, err := repo.getRecord(key.dataSource, key.recordID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, repo.entities[aRecord.entityID])
+	}
+
+	return result, nil
+}
+
+func (repo *Repository) hasAnyDataSource(entityID int64, dataSources []string) bool {
+	if len(dataSources) == 0 {
+		return true
+	}
+
+	for _, key := range repo.entities[entityID].recordKeys {
+		if slices.Contains(dataSources, key.dataSource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Report whether a disclosure relates a record of one entity to a record of another.
+func (repo *Repository) isDisclosed(entity1 *entity, entity2 *entity) bool {
+	for _, aDisclosure := range repo.disclosures {
+		if slices.Contains(entity1.recordKeys, aDisclosure.recordKey1) &&
+			slices.Contains(entity2.recordKeys, aDisclosure.recordKey2) {
+			return true
+		}
+
+		if slices.Contains(entity1.recordKeys, aDisclosure.recordKey2) &&
+			slices.Contains(entity2.recordKeys, aDisclosure.recordKey1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (repo *Repository) newGraph() *graph {
+	return &graph{
+		links: map[int64][]relationship{},
+		repo:  repo,
+	}
+}
+
+// --- Graph ------------------------------------------------------------------
+
+/*
+Method buildOut adds the entities within a number of degrees of the given entities.
+
+Input
+  - members: The entities of the network so far.
+  - degrees: The number of degrees to build out.
+  - maxEntities: The maximum number of entities to add.
+
+Output
+  - The entities of the network, without duplicates, ordered by entity ID.
+*/
+func (aGraph *graph) buildOut(members []int64, degrees int64, maxEntities int64) []int64 {
+	seen := map[int64]bool{}
+	frontier := []int64{}
+
+	for _, entityID := range members {
+		if !seen[entityID] {
+			seen[entityID] = true
+			frontier = append(frontier, entityID)
+		}
+	}
+
+	result := slices.Clone(frontier)
+	added := int64(0)
+
+	for degree := int64(0); degree < degrees && len(frontier) > 0; degree++ {
+		next := []int64{}
+
+		for _, entityID := range frontier {
+			for _, aRelationship := range aGraph.relationships(entityID) {
+				if seen[aRelationship.entityID] {
+					continue
+				}
+
+				if added >= maxEntities {
+					slices.Sort(result)
+
+					return result
+				}
+
+				seen[aRelationship.entityID] = true
+				added++
+				next = append(next, aRelationship.entityID)
+				result = append(result, aRelationship.entityID)
+			}
+		}
+
+		frontier = next
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// Return the entities within maxDegrees of an entity, by their distance from it.
+func (aGraph *graph) degrees(entityID int64, maxDegrees int64) map[int64]int {
+	result := map[int64]int{}
+	seen := map[int64]bool{entityID: true}
+	frontier := []int64{entityID}
+
+	for degree := 1; int64(degree) <= maxDegrees && len(frontier) > 0; degree++ {
+		next := []int64{}
+
+		for _, frontierEntityID := range frontier {
+			for _, aRelationship := range aGraph.relationships(frontierEntityID) {
+				if seen[aRelationship.entityID] {
+					continue
+				}
+
+				seen[aRelationship.entityID] = true
+				result[aRelationship.entityID] = degree
+				next = append(next, aRelationship.entityID)
+			}
+		}
+
+		frontier = next
+	}
+
+	return result
+}
+
+func (aGraph *graph) link(entityID1 int64, entityID2 int64) (entityLinkDocument, bool) {
+	for _, aRelationship := range aGraph.relationships(entityID1) {
+		if aRelationship.entityID == entityID2 {
+			return entityLinkDocument{
+				MinEntityID: min(entityID1, entityID2),
+				MaxEntityID: max(entityID1, entityID2),
+				relatedMatchingInfoDocument: relatedMatchingInfoDocument{
+					IsDisclosed:          boolToInt(aRelationship.match.matchLevel == MatchLevelDisclosed),
+					matchingInfoDocument: aRelationship.match.document(),
+				},
+			}, true
+		}
+	}
+
+	return entityLinkDocument{}, false
+}
+
+// List the links between each pair of related entities.
+func (aGraph *graph) linksAmong(entityIDs []int64) []entityLinkDocument {
+	result := []entityLinkDocument{}
+
+	for index, entityID1 := range entityIDs {
+		for _, entityID2 := range entityIDs[index+1:] {
+			if aLink, found := aGraph.link(entityID1, entityID2); found {
+				result = append(result, aLink)
+			}
+		}
+	}
+
+	return result
+}
+
+// List the links between consecutive entities of a path.
+func (aGraph *graph) linksAlong(path []int64) []entityLinkDocument {
+	result := []entityLinkDocument{}
+
+	for index := 1; index < len(path); index++ {
+		if aLink, found := aGraph.link(path[index-1], path[index]); found {
+			result = append(result, aLink)
+		}
+	}
+
+	return result
+}
+
+func (aGraph *graph) relationships(entityID int64) []relationship {
+	result, found := aGraph.links[entityID]
+	if !found {
+		result = aGraph.repo.relationships(aGraph.repo.entities[entityID])
+		aGraph.links[entityID] = result
+	}
+
+	return result
+}
+
+/*
+Method shortestPath runs a breadth-first search from one entity to another.
+
+The search tracks whether a required data source has been seen along the way,
+so a longer path through a required data source is found when a shorter one lacks it.
+A path never visits an entity twice, so a required data source on a dead end does not satisfy the search.
+
+Output
+  - The entity IDs of the path, from start to end. Empty if no path satisfies the search.
+*/
+func (aGraph *graph) shortestPath(startEntityID int64, endEntityID int64, search pathSearch) []int64 {
+	// A step is the last entity of a path, whose earlier entities are read back through previous.
+	type step struct {
+		entityID  int64
+		previous  *step
+		satisfied bool
+	}
+
+	hasRequired := func(entityID int64) bool {
+		return aGraph.repo.hasAnyDataSource(entityID, search.requiredDataSources)
+	}
+
+	isOnPath := func(current *step, entityID int64) bool {
+		for ; current != nil; current = current.previous {
+			if current.entityID == entityID {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// Without required data sources, the first path to reach an entity is a shortest one, so it is reached once.
+	// Otherwise, every path not visiting an entity twice is searched.
+	reached := map[int64]bool{startEntityID: true}
+	frontier := []*step{{entityID: startEntityID, satisfied: hasRequired(startEntityID)}}
+
+	for degree := int64(0); len(frontier) > 0; degree++ {
+		next := []*step{}
+
+		for _, current := range frontier {
+			if current.entityID == endEntityID && current.satisfied {
+				result := []int64{}
+				for ; current != nil; current = current.previous {
+					result = append(result, current.entityID)
+				}
+
+				slices.Reverse(result)
+
+				return result
+			}
+
+			if current.entityID == endEntityID || degree >= search.maxDegrees {
+				continue
+			}
+
+			for _, aRelationship := range aGraph.relationships(current.entityID) {
+				entityID := aRelationship.entityID
+				if search.avoid[entityID] && entityID != endEntityID {
+					continue
+				}
+
+				if len(search.requiredDataSources) == 0 {
+					if reached[entityID] {
+						continue
+					}
+
+					reached[entityID] = true
+				} else if isOnPath(current, entityID) {
+					continue
+				}
+
+				next = append(next, &step{
+					entityID:  entityID,
+					previous:  current,
+					satisfied: current.satisfied || hasRequired(entityID),
+				})
+			}
+		}
+
+		frontier = next
+	}
+
+	return []int64{}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newPathSearch(maxDegrees int64, requiredDataSources string) (pathSearch, error) {
+	dataSources, err := parseDataSources(requiredDataSources)
+
+	return pathSearch{
+		avoid:               map[int64]bool{},
+		maxDegrees:          maxDegrees,
+		requiredDataSources: dataSources,
+	}, err
+}
+
+// Parse `{"DATA_SOURCES": ["..."]}`. An empty string lists no data sources.
+func parseDataSources(dataSources string) ([]string, error) {
+	document := struct {
+		DataSources []string `json:"DATA_SOURCES"`
+	}{}
+
+	err := parseParameter(dataSources, &document)
+
+	return document.DataSources, err
+}
+
+// Parse `{"ENTITIES": [{"ENTITY_ID": 1}]}`. An empty string lists no entities.
+func parseEntityIDs(entityIDs string) ([]int64, error) {
+	document := struct {
+		Entities []struct {
+			EntityID int64 `json:"ENTITY_ID"`
+		} `json:"ENTITIES"`
+	}{}
+
+	err := parseParameter(entityIDs, &document)
+	result := make([]int64, 0, len(document.Entities))
+
+	for _, anEntity := range document.Entities {
+		result = append(result, anEntity.EntityID)
+	}
+
+	return result, err
+}
+
+// Parse `{"RECORDS": [{"DATA_SOURCE": "...", "RECORD_ID": "..."}]}`. An empty string lists no records.
+func parseRecordKeys(recordKeys string) ([]recordKey, error) {
+	document := struct {
+		Records []struct {
+			DataSource string `json:"DATA_SOURCE"`
+			RecordID   string `json:"RECORD_ID"`
+		} `json:"RECORDS"`
+	}{}
+
+	err := parseParameter(recordKeys, &document)
+	result := make([]recordKey, 0, len(document.Records))
+
+	for _, aRecord := range document.Records {
+		result = append(result, recordKey{dataSource: aRecord.DataSource, recordID: aRecord.RecordID})
+	}
+
+	return result, err
+}
+
+/*
+Function parseParameter parses a JSON parameter into a document.
+
+Input
+  - parameter: The JSON text. An empty string leaves the document unchanged.
+  - document: A pointer to the document.
+
+Output
+  - A szerror bad-input error if the parameter is not valid JSON for the document.
+*/
+func parseParameter(parameter string, document any) error {
+	if len(strings.TrimSpace(parameter)) == 0 {
+		return nil
+	}
+
+	err := json.Unmarshal([]byte(parameter), document)
+	if err != nil {
+		return newError(2, "Invalid Message: %s", parameter)
+	}
+
+	return nil
+}
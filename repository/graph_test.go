@@ -0,0 +1,337 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphResponse struct {
+	Entities []struct {
+		ResolvedEntity struct {
+			EntityID int64 `json:"ENTITY_ID"`
+		} `json:"RESOLVED_ENTITY"`
+	} `json:"ENTITIES"`
+	EntityPaths []struct {
+		Entities []int64 `json:"ENTITIES"`
+	} `json:"ENTITY_PATHS"`
+	EntityPathLinks []struct {
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+		IsDisclosed    int    `json:"IS_DISCLOSED"`
+	} `json:"ENTITY_PATH_LINKS"`
+}
+
+// Records of the test graph. A-B-D is the short way from A to D; A-C-E-D goes through the WATCHLIST.
+var graphRecords = map[string]record.Record{
+	"A": {DataSource: "CUSTOMERS", ID: "A", JSON: `{"NAME_FULL": "ALICE ADAMS"}`},
+	"B": {DataSource: "CUSTOMERS", ID: "B", JSON: `{"NAME_FULL": "BRUNO BAKER"}`},
+	"C": {DataSource: "WATCHLIST", ID: "C", JSON: `{"NAME_FULL": "CARLA CRUZ"}`},
+	"D": {DataSource: "CUSTOMERS", ID: "D", JSON: `{"NAME_FULL": "DIEGO DIAZ"}`},
+	"E": {DataSource: "CUSTOMERS", ID: "E", JSON: `{"NAME_FULL": "ELENA EVANS"}`},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_FindPathByEntityID(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+
+	testCases := []struct {
+		name                string
+		maxDegrees          int64
+		avoid               []string
+		requiredDataSources string
+		flags               int64
+		expected            []string
+	}{
+		{name: "shortest", maxDegrees: 3, expected: []string{"A", "B", "D"}},
+		{name: "too few degrees", maxDegrees: 1, expected: []string{}},
+		{name: "avoid", maxDegrees: 3, avoid: []string{"B"}, expected: []string{"A", "C", "E", "D"}},
+		{name: "avoid when unavoidable", maxDegrees: 3, avoid: []string{"B", "C"}, expected: []string{"A", "B", "D"}},
+		{
+			name:       "strict avoid",
+			maxDegrees: 3,
+			avoid:      []string{"B", "C"},
+			flags:      senzing.SzFindPathStrictAvoid,
+			expected:   []string{},
+		},
+		{
+			name:                "required data source",
+			maxDegrees:          3,
+			requiredDataSources: `{"DATA_SOURCES": ["WATCHLIST"]}`,
+			expected:            []string{"A", "C", "E", "D"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			avoidEntityIDs := struct {
+				Entities []map[string]int64 `json:"ENTITIES"`
+			}{}
+			for _, name := range testCase.avoid {
+				avoidEntityIDs.Entities = append(avoidEntityIDs.Entities, map[string]int64{"ENTITY_ID": ids[name]})
+			}
+
+			avoid, err := json.Marshal(avoidEntityIDs)
+			require.NoError(test, err)
+
+			actual, err := repo.FindPathByEntityID(
+				ctx,
+				ids["A"],
+				ids["D"],
+				testCase.maxDegrees,
+				string(avoid),
+				testCase.requiredDataSources,
+				testCase.flags,
+			)
+			require.NoError(test, err)
+
+			response := &graphResponse{}
+			require.NoError(test, json.Unmarshal([]byte(actual), response))
+			require.Len(test, response.EntityPaths, 1)
+			assert.Equal(test, entityIDsOf(ids, testCase.expected), response.EntityPaths[0].Entities)
+		})
+	}
+}
+
+func TestRepository_FindPathByEntityID_matchingInfo(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+
+	actual, err := repo.FindPathByEntityID(ctx, ids["A"], ids["D"], 3, "", "", senzing.SzFindPathDefaultFlags)
+	require.NoError(test, err)
+
+	response := &graphResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Len(test, response.Entities, 3)
+	require.Len(test, response.EntityPathLinks, 2)
+
+	for _, link := range response.EntityPathLinks {
+		assert.Equal(test, repository.MatchLevelDisclosed, link.MatchLevelCode)
+		assert.Equal(test, 1, link.IsDisclosed)
+	}
+}
+
+func TestRepository_FindPathByEntityID_badParameters(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+
+	testCases := []struct {
+		name                string
+		avoidEntityIDs      string
+		requiredDataSources string
+	}{
+		{name: "avoidEntityIDs", avoidEntityIDs: "}{"},
+		{name: "avoidEntityIDs shape", avoidEntityIDs: `{"ENTITIES": [{"ENTITY_ID": "A"}]}`},
+		{name: "requiredDataSources", requiredDataSources: "}{"},
+		{name: "requiredDataSources shape", requiredDataSources: `{"DATA_SOURCES": "CUSTOMERS"}`},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			_, err := repo.FindPathByEntityID(
+				ctx,
+				ids["A"],
+				ids["D"],
+				3,
+				testCase.avoidEntityIDs,
+				testCase.requiredDataSources,
+				senzing.SzNoFlags,
+			)
+			require.ErrorIs(test, err, szerror.ErrSzBadInput)
+		})
+	}
+}
+
+func TestRepository_FindPathByRecordID(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+
+	actual, err := repo.FindPathByRecordID(
+		ctx,
+		"CUSTOMERS", "A",
+		"CUSTOMERS", "D",
+		3,
+		`{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "B"}]}`,
+		"",
+		senzing.SzNoFlags,
+	)
+	require.NoError(test, err)
+
+	response := &graphResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	require.Len(test, response.EntityPaths, 1)
+	assert.Equal(test, entityIDsOf(ids, []string{"A", "C", "E", "D"}), response.EntityPaths[0].Entities)
+
+	_, err = repo.FindPathByRecordID(ctx, "CUSTOMERS", "A", "CUSTOMERS", "Z", 3, "", "", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+
+	_, err = repo.FindPathByRecordID(ctx, "CUSTOMERS", "A", "CUSTOMERS", "D", 3, "}{", "", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestRepository_FindPathByRecordID_requiredDataSourceOnDeadEnd(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	records := map[string]record.Record{
+		"S": {DataSource: "CUSTOMERS", ID: "S", JSON: `{"NAME_FULL": "SARA SCOTT"}`},
+		"X": {DataSource: "CUSTOMERS", ID: "X", JSON: `{"NAME_FULL": "XAVIER XU"}`},
+		"R": {DataSource: "WATCHLIST", ID: "R", JSON: `{"NAME_FULL": "RITA ROSS"}`},
+		"E": {DataSource: "CUSTOMERS", ID: "E", JSON: `{"NAME_FULL": "ELENA EVANS"}`},
+	}
+	ids := map[string]int64{}
+
+	for _, name := range []string{"S", "X", "R", "E"} {
+		addRecords(ctx, test, repo, records[name])
+		ids[name] = getEntityID(test, repo, records[name])
+	}
+
+	for _, pair := range [][2]string{{"S", "X"}, {"X", "R"}, {"X", "E"}} {
+		record1 := records[pair[0]]
+		record2 := records[pair[1]]
+		repo.DiscloseRelationship(record1.DataSource, record1.ID, record2.DataSource, record2.ID)
+	}
+
+	findPath := func() *graphResponse {
+		actual, err := repo.FindPathByRecordID(
+			ctx,
+			"CUSTOMERS", "S",
+			"CUSTOMERS", "E",
+			4,
+			"",
+			`{"DATA_SOURCES": ["WATCHLIST"]}`,
+			senzing.SzNoFlags,
+		)
+		require.NoError(test, err)
+
+		response := &graphResponse{}
+		require.NoError(test, json.Unmarshal([]byte(actual), response))
+		require.Len(test, response.EntityPaths, 1)
+
+		return response
+	}
+
+	// Going through R means visiting X twice, so no path satisfies the search.
+	response := findPath()
+	assert.Empty(test, response.EntityPaths[0].Entities)
+	require.Len(test, response.Entities, 2)
+
+	repo.DiscloseRelationship("WATCHLIST", "R", "CUSTOMERS", "E")
+
+	response = findPath()
+	assert.Equal(test, entityIDsOf(ids, []string{"S", "X", "R", "E"}), response.EntityPaths[0].Entities)
+	require.Len(test, response.Entities, 4)
+}
+
+func TestRepository_FindNetworkByEntityID(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+	entityIDs := `{"ENTITIES": [{"ENTITY_ID": ` + formatID(ids["A"]) + `}, {"ENTITY_ID": ` + formatID(ids["D"]) + `}]}`
+
+	testCases := []struct {
+		name                string
+		buildOutDegrees     int64
+		buildOutMaxEntities int64
+		expected            []string
+	}{
+		{name: "no build out", expected: []string{"A", "B", "D"}},
+		{name: "build out", buildOutDegrees: 1, buildOutMaxEntities: 10, expected: []string{"A", "B", "C", "D", "E"}},
+		{name: "build out limited", buildOutDegrees: 1, buildOutMaxEntities: 1, expected: []string{"A", "B", "C", "D"}},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := repo.FindNetworkByEntityID(
+				ctx,
+				entityIDs,
+				2,
+				testCase.buildOutDegrees,
+				testCase.buildOutMaxEntities,
+				senzing.SzNoFlags,
+			)
+			require.NoError(test, err)
+
+			response := &graphResponse{}
+			require.NoError(test, json.Unmarshal([]byte(actual), response))
+			require.Len(test, response.EntityPaths, 1)
+			assert.Equal(test, entityIDsOf(ids, []string{"A", "B", "D"}), response.EntityPaths[0].Entities)
+
+			actualIDs := []int64{}
+			for _, anEntity := range response.Entities {
+				actualIDs = append(actualIDs, anEntity.ResolvedEntity.EntityID)
+			}
+
+			assert.Equal(test, entityIDsOf(ids, testCase.expected), actualIDs)
+		})
+	}
+}
+
+func TestRepository_FindNetworkByRecordID_unknownRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, _ := getGraphRepository(ctx, test)
+	_, err := repo.FindNetworkByRecordID(
+		ctx,
+		`{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "Z"}]}`,
+		2,
+		0,
+		0,
+		senzing.SzNoFlags,
+	)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func entityIDsOf(ids map[string]int64, names []string) []int64 {
+	result := []int64{}
+	for _, name := range names {
+		result = append(result, ids[name])
+	}
+
+	return result
+}
+
+// Build the graph of graphRecords and return the entity ID of each record.
+func getGraphRepository(ctx context.Context, test *testing.T) (*repository.Repository, map[string]int64) {
+	test.Helper()
+
+	repo := &repository.Repository{}
+	ids := map[string]int64{}
+
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		addRecords(ctx, test, repo, graphRecords[name])
+		ids[name] = getEntityID(test, repo, graphRecords[name])
+	}
+
+	for _, pair := range [][2]string{{"A", "B"}, {"B", "D"}, {"A", "C"}, {"C", "E"}, {"E", "D"}} {
+		record1 := graphRecords[pair[0]]
+		record2 := graphRecords[pair[1]]
+		repo.DiscloseRelationship(record1.DataSource, record1.ID, record2.DataSource, record2.ID)
+	}
+
+	return repo, ids
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type howDocument struct {
+	HowResults howResultsDocument `json:"HOW_RESULTS"`
+}
+
+type howFinalStateDocument struct {
+	NeedReevaluation int                     `json:"NEED_REEVALUATION"`
+	VirtualEntities  []virtualEntityDocument `json:"VIRTUAL_ENTITIES"`
+}
+
+type howMatchInfoDocument struct {
+	ErruleCode string `json:"ERRULE_CODE"`
+	MatchKey   string `json:"MATCH_KEY"`
+}
+
+type howResultsDocument struct {
+	FinalState      howFinalStateDocument    `json:"FINAL_STATE"`
+	ResolutionSteps []resolutionStepDocument `json:"RESOLUTION_STEPS"`
+}
+
+type memberRecordDocument struct {
+	InternalID int64                 `json:"INTERNAL_ID"`
+	Records    []focusRecordDocument `json:"RECORDS"`
+}
+
+type resolutionStepDocument struct {
+	InboundVirtualEntityID string                `json:"INBOUND_VIRTUAL_ENTITY_ID"`
+	MatchInfo              howMatchInfoDocument  `json:"MATCH_INFO"`
+	ResultVirtualEntityID  string                `json:"RESULT_VIRTUAL_ENTITY_ID"`
+	Step                   int                   `json:"STEP"`
+	VirtualEntity1         virtualEntityDocument `json:"VIRTUAL_ENTITY_1"`
+	VirtualEntity2         virtualEntityDocument `json:"VIRTUAL_ENTITY_2"`
+}
+
+// A resolutionStep records the records of an entity being joined by inbound records.
+type resolutionStep struct {
+	inbound []recordKey
+	match   matchInfo
+	records []recordKey
+}
+
+// A virtualEntity is an entity as it stood at some step of its resolution.
+type virtualEntity struct {
+	base       string // The ID of the virtual entity the steps started from, e.g. "V1".
+	id         string
+	recordKeys []recordKey
+}
+
+type virtualEntityDocument struct {
+	MemberRecords   []memberRecordDocument `json:"MEMBER_RECORDS"`
+	VirtualEntityID string                 `json:"VIRTUAL_ENTITY_ID"`
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method HowEntityByEntityID replays the merges that built an entity.
+
+Each record starts as a virtual entity named after its internal ID, e.g. "V1".
+Each step merges an inbound virtual entity into another; the result is named
+after the virtual entity the steps started from and the step number, e.g. "V1-S2".
+Steps involving deleted records are left out.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID: The unique identifier of an entity.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document.
+*/
+func (repo *Repository) HowEntityByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
+	_ = ctx
+	_ = flags
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("howEntityByEntityID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	anEntity, err := repo.getEntity(entityID)
+	if err != nil {
+		return "", err
+	}
+
+	virtualEntities := map[recordKey]*virtualEntity{}
+
+	for _, key := range anEntity.recordKeys {
+		id := fmt.Sprintf("V%d", repo.records[key].internalID)
+		virtualEntities[key] = &virtualEntity{base: id, id: id, recordKeys: []recordKey{key}}
+	}
+
+	result := howDocument{
+		HowResults: howResultsDocument{
+			ResolutionSteps: []resolutionStepDocument{},
+		},
+	}
+
+	for _, aStep := range repo.resolutionSteps(anEntity) {
+		virtualEntity1 := findVirtualEntity(virtualEntities, aStep.records)
+		virtualEntity2 := findVirtualEntity(virtualEntities, aStep.inbound)
+
+		if virtualEntity1 == nil || virtualEntity2 == nil || virtualEntity1 == virtualEntity2 {
+			continue
+		}
+
+		stepNumber := len(result.HowResults.ResolutionSteps) + 1
+		merged := &virtualEntity{
+			base:       virtualEntity1.base,
+			id:         fmt.Sprintf("%s-S%d", virtualEntity1.base, stepNumber),
+			recordKeys: slices.Concat(virtualEntity1.recordKeys, virtualEntity2.recordKeys),
+		}
+
+		result.HowResults.ResolutionSteps = append(result.HowResults.ResolutionSteps, resolutionStepDocument{
+			InboundVirtualEntityID: virtualEntity2.id,
+			MatchInfo: howMatchInfoDocument{
+				ErruleCode: aStep.match.errRuleCode,
+				MatchKey:   aStep.match.matchKey,
+			},
+			ResultVirtualEntityID: merged.id,
+			Step:                  stepNumber,
+			VirtualEntity1:        repo.virtualEntityDocument(virtualEntity1),
+			VirtualEntity2:        repo.virtualEntityDocument(virtualEntity2),
+		})
+
+		for _, key := range merged.recordKeys {
+			virtualEntities[key] = merged
+		}
+	}
+
+	result.HowResults.FinalState.VirtualEntities = []virtualEntityDocument{}
+	seen := []*virtualEntity{}
+
+	for _, key := range anEntity.recordKeys {
+		if slices.Contains(seen, virtualEntities[key]) {
+			continue
+		}
+
+		seen = append(seen, virtualEntities[key])
+		result.HowResults.FinalState.VirtualEntities = append(
+			result.HowResults.FinalState.VirtualEntities,
+			repo.virtualEntityDocument(virtualEntities[key]),
+		)
+	}
+
+	return marshal(result)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the resolution steps of an entity, leaving out those whose inbound or joined records were all deleted.
+func (repo *Repository) resolutionSteps(anEntity *entity) []resolutionStep {
+	result := []resolutionStep{}
+	isMember := func(key recordKey) bool {
+		return slices.Contains(anEntity.recordKeys, key)
+	}
+
+	for _, aStep := range anEntity.steps {
+		if slices.ContainsFunc(aStep.inbound, isMember) && slices.ContainsFunc(aStep.records, isMember) {
+			result = append(result, aStep)
+		}
+	}
+
+	return result
+}
+
+func (repo *Repository) virtualEntityDocument(aVirtualEntity *virtualEntity) virtualEntityDocument {
+	result := virtualEntityDocument{
+		MemberRecords:   make([]memberRecordDocument, 0, len(aVirtualEntity.recordKeys)),
+		VirtualEntityID: aVirtualEntity.id,
+	}
+
+	for _, key := range aVirtualEntity.recordKeys {
+		aRecord := repo.records[key]
+		result.MemberRecords = append(result.MemberRecords, memberRecordDocument{
+			InternalID: aRecord.internalID,
+			Records:    []focusRecordDocument{aRecord.focusDocument()},
+		})
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the virtual entity holding the first of the records still in the entity.
+func findVirtualEntity(virtualEntities map[recordKey]*virtualEntity, keys []recordKey) *virtualEntity {
+	for _, key := range keys {
+		if aVirtualEntity, found := virtualEntities[key]; found {
+			return aVirtualEntity
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type howResponse struct {
+	HowResults struct {
+		FinalState struct {
+			VirtualEntities []virtualEntityResponse `json:"VIRTUAL_ENTITIES"`
+		} `json:"FINAL_STATE"`
+		ResolutionSteps []struct {
+			InboundVirtualEntityID string `json:"INBOUND_VIRTUAL_ENTITY_ID"`
+			MatchInfo              struct {
+				ErruleCode string `json:"ERRULE_CODE"`
+				MatchKey   string `json:"MATCH_KEY"`
+			} `json:"MATCH_INFO"`
+			ResultVirtualEntityID string                `json:"RESULT_VIRTUAL_ENTITY_ID"`
+			Step                  int                   `json:"STEP"`
+			VirtualEntity1        virtualEntityResponse `json:"VIRTUAL_ENTITY_1"`
+			VirtualEntity2        virtualEntityResponse `json:"VIRTUAL_ENTITY_2"`
+		} `json:"RESOLUTION_STEPS"`
+	} `json:"HOW_RESULTS"`
+}
+
+type virtualEntityResponse struct {
+	MemberRecords   []json.RawMessage `json:"MEMBER_RECORDS"`
+	VirtualEntityID string            `json:"VIRTUAL_ENTITY_ID"`
+}
+
+// H3 bridges H1 and H2, which only share a name.
+var howRecords = []record.Record{
+	{DataSource: "CUSTOMERS", ID: "H1", JSON: `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`},
+	{DataSource: "CUSTOMERS", ID: "H2", JSON: `{"NAME_FULL": "ROBERT SMITH", "DRIVERS_LICENSE_NUMBER": "D123"}`},
+	{
+		DataSource: "CUSTOMERS",
+		ID:         "H3",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789", "DRIVERS_LICENSE_NUMBER": "D123"}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_HowEntityByEntityID(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, howRecords...)
+	entityID := getEntityID(test, repo, howRecords[0])
+
+	actual, err := repo.HowEntityByEntityID(ctx, entityID, senzing.SzHowEntityDefaultFlags)
+	require.NoError(test, err)
+
+	response := &howResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	steps := response.HowResults.ResolutionSteps
+	require.Len(test, steps, 2)
+	assert.Equal(test, 1, steps[0].Step)
+	assert.Equal(test, "V1", steps[0].VirtualEntity1.VirtualEntityID)
+	assert.Equal(test, "V3", steps[0].InboundVirtualEntityID)
+	assert.Equal(test, "V1-S1", steps[0].ResultVirtualEntityID)
+	assert.Equal(test, "SF1_CNAME", steps[0].MatchInfo.ErruleCode)
+	assert.Equal(test, "+NAME+SSN", steps[0].MatchInfo.MatchKey)
+	assert.Equal(test, 2, steps[1].Step)
+	assert.Equal(test, "V1-S1", steps[1].VirtualEntity1.VirtualEntityID)
+	assert.Len(test, steps[1].VirtualEntity1.MemberRecords, 2)
+	assert.Equal(test, "V2", steps[1].InboundVirtualEntityID)
+	assert.Equal(test, "V1-S2", steps[1].ResultVirtualEntityID)
+	assert.Equal(test, "+NAME+DRLIC", steps[1].MatchInfo.MatchKey)
+
+	finalState := response.HowResults.FinalState.VirtualEntities
+	require.Len(test, finalState, 1)
+	assert.Equal(test, "V1-S2", finalState[0].VirtualEntityID)
+	assert.Len(test, finalState[0].MemberRecords, 3)
+}
+
+func TestRepository_HowEntityByEntityID_singleRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, howRecords[0])
+
+	actual, err := repo.HowEntityByEntityID(ctx, getEntityID(test, repo, howRecords[0]), senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	response := &howResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Empty(test, response.HowResults.ResolutionSteps)
+	require.Len(test, response.HowResults.FinalState.VirtualEntities, 1)
+	assert.Equal(test, "V1", response.HowResults.FinalState.VirtualEntities[0].VirtualEntityID)
+
+	_, err = repo.HowEntityByEntityID(ctx, 1, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type findInterestingEntitiesDocument struct {
+	InterestingEntities interestingEntitiesDocument `json:"INTERESTING_ENTITIES"`
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method FindInterestingEntitiesByEntityID finds the entities the interest rules flag near an entity.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID: The unique identifier of an entity.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document listing the interesting entities, nearest first.
+*/
+func (repo *Repository) FindInterestingEntitiesByEntityID(
+	ctx context.Context,
+	entityID int64,
+	flags int64,
+) (string, error) {
+	_ = ctx
+	_ = flags
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("findInterestingEntitiesByEntityID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	anEntity, err := repo.getEntity(entityID)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(findInterestingEntitiesDocument{
+		InterestingEntities: repo.interestingEntitiesDocument([]int64{anEntity.id}),
+	})
+}
+
+/*
+Method FindInterestingEntitiesByRecordID finds the entities the interest rules flag near the entity of a record.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document listing the interesting entities, nearest first.
+*/
+func (repo *Repository) FindInterestingEntitiesByRecordID(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+	_ = flags
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("findInterestingEntitiesByRecordID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	aRecord, err := repo.getRecord(dataSourceCode, recordID)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(findInterestingEntitiesDocument{
+		InterestingEntities: repo.interestingEntitiesDocument([]int64{aRecord.entityID}),
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+/*
+Method interestingEntitiesDocument evaluates the interest rules around a set of focus entities.
+
+Focus entities that no longer exist are ignored, and focus entities are never reported.
+An entity found by several rules is reported once, at its smallest distance, with the sorted flags of those rules.
+
+Input
+  - focus: The IDs of the entities to look around.
+
+Output
+  - The interesting entities, nearest first.
+*/
+func (repo *Repository) interestingEntitiesDocument(focus []int64) interestingEntitiesDocument {
+	found := map[int64]*interestingEntityDocument{}
+	aGraph := repo.newGraph()
+
+	for _, focusEntityID := range focus {
+		focusEntity, exists := repo.entities[focusEntityID]
+		if !exists {
+			continue
+		}
+
+		for _, anInterestRule := range repo.InterestRules {
+			for entityID, degrees := range aGraph.degrees(focusEntityID, max(anInterestRule.MaxDegrees, 1)) {
+				if slices.Contains(focus, entityID) || !repo.isInteresting(anInterestRule, focusEntity, entityID) {
+					continue
+				}
+
+				anInterestingEntity, seen := found[entityID]
+				if !seen {
+					anInterestingEntity = &interestingEntityDocument{
+						EntityID: entityID,
+						Degrees:  degrees,
+						Flags:    []string{},
+					}
+					found[entityID] = anInterestingEntity
+				}
+
+				anInterestingEntity.Degrees = min(anInterestingEntity.Degrees, degrees)
+
+				if !slices.Contains(anInterestingEntity.Flags, anInterestRule.Flag) {
+					anInterestingEntity.Flags = append(anInterestingEntity.Flags, anInterestRule.Flag)
+				}
+			}
+		}
+	}
+
+	result := interestingEntitiesDocument{
+		Entities: make([]interestingEntityDocument, 0, len(found)),
+	}
+
+	for _, anInterestingEntity := range found {
+		slices.Sort(anInterestingEntity.Flags)
+		result.Entities = append(result.Entities, *anInterestingEntity)
+	}
+
+	slices.SortFunc(result.Entities, func(entity1 interestingEntityDocument, entity2 interestingEntityDocument) int {
+		return cmp.Or(cmp.Compare(entity1.Degrees, entity2.Degrees), cmp.Compare(entity1.EntityID, entity2.EntityID))
+	})
+
+	return result
+}
+
+// Report whether an entity satisfies the data source and shared feature conditions of an interest rule.
+func (repo *Repository) isInteresting(anInterestRule InterestRule, focusEntity *entity, entityID int64) bool {
+	if !repo.hasAnyDataSource(entityID, anInterestRule.DataSources) {
+		return false
+	}
+
+	if len(anInterestRule.SharedFeatures) == 0 {
+		return true
+	}
+
+	for _, focusKey := range focusEntity.recordKeys {
+		for _, key := range repo.entities[entityID].recordKeys {
+			shared := agreeingFeatureTypes(repo.records[focusKey].features, repo.records[key].features)
+			for _, featureType := range anInterestRule.SharedFeatures {
+				if slices.Contains(shared, featureType) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type interestingEntitiesResponse struct {
+	InterestingEntities struct {
+		Entities []struct {
+			EntityID int64    `json:"ENTITY_ID"`
+			Degrees  int      `json:"DEGREES"`
+			Flags    []string `json:"FLAGS"`
+		} `json:"ENTITIES"`
+	} `json:"INTERESTING_ENTITIES"`
+}
+
+var watchlistInterestRule = repository.InterestRule{
+	DataSources: []string{"WATCHLIST"},
+	Flag:        "WATCHLIST",
+	MaxDegrees:  2,
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_FindInterestingEntitiesByEntityID(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+	repo.InterestRules = []repository.InterestRule{watchlistInterestRule}
+
+	testCases := []struct {
+		name     string
+		focus    string
+		expected []string // ENTITY_ID name, DEGREES and FLAGS of each interesting entity.
+	}{
+		{name: "1 degree", focus: "A", expected: []string{"C 1 [WATCHLIST]"}},
+		{name: "2 degrees", focus: "D", expected: []string{"C 2 [WATCHLIST]"}},
+		{name: "interesting focus", focus: "C", expected: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := repo.FindInterestingEntitiesByEntityID(ctx, ids[testCase.focus], senzing.SzNoFlags)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, interestingEntities(test, ids, actual))
+		})
+	}
+
+	_, err := repo.FindInterestingEntitiesByEntityID(ctx, 1, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_FindInterestingEntitiesByRecordID_sharedFeatures(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	records := map[string]record.Record{
+		"F1": {
+			DataSource: "CUSTOMERS",
+			ID:         "F1",
+			JSON:       `{"NAME_FULL": "ROBERT SMITH", "PHONE_NUMBER": "702-555-1212"}`,
+		},
+		"F2": {DataSource: "CUSTOMERS", ID: "F2", JSON: `{"NAME_FULL": "JANE DOE", "PHONE_NUMBER": "702-555-1212"}`},
+		"F3": {DataSource: "WATCHLIST", ID: "F3", JSON: `{"NAME_FULL": "MARY JONES", "PHONE_NUMBER": "702-555-1212"}`},
+	}
+	repo := &repository.Repository{
+		InterestRules: []repository.InterestRule{
+			watchlistInterestRule,
+			{Flag: "SHARED_PHONE", SharedFeatures: []string{"PHONE"}},
+		},
+	}
+	ids := map[string]int64{}
+
+	for _, name := range []string{"F1", "F2", "F3"} {
+		addRecords(ctx, test, repo, records[name])
+		ids[name] = getEntityID(test, repo, records[name])
+	}
+
+	actual, err := repo.FindInterestingEntitiesByRecordID(ctx, "CUSTOMERS", "F1", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(
+		test,
+		[]string{"F2 1 [SHARED_PHONE]", "F3 1 [SHARED_PHONE WATCHLIST]"},
+		interestingEntities(test, ids, actual),
+	)
+
+	_, err = repo.FindInterestingEntitiesByRecordID(ctx, "CUSTOMERS", "Z", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_AddRecord_withInfo_interestingEntities(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getGraphRepository(ctx, test)
+	repo.InterestRules = []repository.InterestRule{watchlistInterestRule}
+	repo.DiscloseRelationship("CUSTOMERS", "Z", "CUSTOMERS", "E")
+
+	actual, err := repo.AddRecord(ctx, "CUSTOMERS", "Z", `{"NAME_FULL": "ZOE ZIMMER"}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"C 2 [WATCHLIST]"}, interestingEntities(test, ids, actual))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Describe each interesting entity as its name in ids, its DEGREES and its FLAGS.
+func interestingEntities(test *testing.T, ids map[string]int64, actual string) []string {
+	test.Helper()
+
+	response := &interestingEntitiesResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	names := map[int64]string{}
+	for name, entityID := range ids {
+		names[entityID] = name
+	}
+
+	result := []string{}
+	for _, anEntity := range response.InterestingEntities.Entities {
+		result = append(result, fmt.Sprintf("%s %d %v", names[anEntity.EntityID], anEntity.Degrees, anEntity.Flags))
+	}
+
+	return result
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A libraryFeature is a distinct normalized feature, as first seen in a loaded record.
+type libraryFeature struct {
+	elements    []element
+	featureType string
+	id          int64
+}
+
+type libraryElementDocument struct {
+	FelemCode  string `json:"FELEM_CODE"`
+	FelemValue string `json:"FELEM_VALUE"`
+}
+
+type libraryFeatureDocument struct {
+	LibFeatID int64                    `json:"LIB_FEAT_ID"`
+	FtypeCode string                   `json:"FTYPE_CODE"`
+	Elements  []libraryElementDocument `json:"ELEMENTS"`
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method GetFeature describes a feature of the feature library.
+
+Every distinct normalized feature of the records added to the repository has a LIB_FEAT_ID,
+numbered from 1 in the order the features were first seen.
+Features are kept in the library when their records are deleted, as they are by the native binary.
+
+Input
+  - ctx: A context to control lifecycle.
+  - featureID: The LIB_FEAT_ID of the feature.
+
+Output
+  - A JSON document describing the feature and its elements.
+*/
+func (repo *Repository) GetFeature(ctx context.Context, featureID int64) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getFeature", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	if featureID < 1 || featureID > int64(len(repo.libraryFeatures)) {
+		return "", newError(57, "Unknown feature ID value '%d'", featureID)
+	}
+
+	aLibraryFeature := repo.libraryFeatures[featureID-1]
+	result := libraryFeatureDocument{
+		LibFeatID: aLibraryFeature.id,
+		FtypeCode: aLibraryFeature.featureType,
+		Elements:  make([]libraryElementDocument, 0, len(aLibraryFeature.elements)),
+	}
+
+	for _, anElement := range aLibraryFeature.elements {
+		result.Elements = append(result.Elements, libraryElementDocument{
+			FelemCode:  anElement.code,
+			FelemValue: anElement.value,
+		})
+	}
+
+	return marshal(result)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Add the features not yet in the feature library.
+func (repo *Repository) addLibraryFeatures(features []feature) {
+	for _, aFeature := range features {
+		libraryKey := aFeature.libraryKey()
+		if _, found := repo.libraryFeatureIDs[libraryKey]; found {
+			continue
+		}
+
+		aLibraryFeature := &libraryFeature{
+			elements:    aFeature.elements,
+			featureType: aFeature.featureType,
+			id:          int64(len(repo.libraryFeatures)) + 1,
+		}
+		repo.libraryFeatures = append(repo.libraryFeatures, aLibraryFeature)
+		repo.libraryFeatureIDs[libraryKey] = aLibraryFeature.id
+	}
+}
+
+// Return the LIB_FEAT_ID of a feature, or 0 if the feature is not in the feature library.
+func (repo *Repository) libraryFeatureID(aFeature feature) int64 {
+	return repo.libraryFeatureIDs[aFeature.libraryKey()]
+}
@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_GetFeature(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	record1 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R1",
+		JSON:       `{"NAME_FIRST": "Robert", "NAME_LAST": "Smith", "SSN_NUMBER": "123-45-6789"}`,
+	}
+	record2 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R2",
+		JSON:       `{"NAME_FIRST": "ROBERT", "NAME_LAST": "SMITH", "PHONE_NUMBER": "702-555-1212"}`,
+	}
+	addRecords(ctx, test, repo, record1, record2)
+
+	testCases := []struct {
+		name      string
+		featureID int64
+		expected  string
+	}{
+		{
+			name:      "name",
+			featureID: 1,
+			expected: `{"LIB_FEAT_ID":1,"FTYPE_CODE":"NAME","ELEMENTS":[` +
+				`{"FELEM_CODE":"GIVEN_NAME","FELEM_VALUE":"Robert"},{"FELEM_CODE":"SUR_NAME","FELEM_VALUE":"Smith"}]}`,
+		},
+		{
+			name:      "ssn",
+			featureID: 2,
+			expected:  `{"LIB_FEAT_ID":2,"FTYPE_CODE":"SSN","ELEMENTS":[{"FELEM_CODE":"ID_NUM","FELEM_VALUE":"123-45-6789"}]}`,
+		},
+		{
+			name:      "phone",
+			featureID: 3,
+			expected: `{"LIB_FEAT_ID":3,"FTYPE_CODE":"PHONE",` +
+				`"ELEMENTS":[{"FELEM_CODE":"PHONE_NUM","FELEM_VALUE":"702-555-1212"}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := repo.GetFeature(ctx, testCase.featureID)
+			require.NoError(test, err)
+			assert.JSONEq(test, testCase.expected, actual)
+		})
+	}
+
+	for _, featureID := range []int64{0, 4} {
+		_, err := repo.GetFeature(ctx, featureID)
+		require.ErrorIs(test, err, szerror.ErrSz)
+	}
+}
+
+func TestRepository_GetFeature_entityFeatures(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R1",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "PHONE_NUMBER": "702-555-1212"}`,
+	}
+	addRecords(ctx, test, repo, aRecord)
+
+	actual, err := repo.GetEntityByRecordID(
+		ctx,
+		aRecord.DataSource,
+		aRecord.ID,
+		senzing.SzEntityIncludeEntityName|senzing.SzEntityIncludeRepresentativeFeatures,
+	)
+	require.NoError(test, err)
+
+	response := &struct {
+		ResolvedEntity struct {
+			Features map[string][]struct {
+				LibFeatID int64 `json:"LIB_FEAT_ID"`
+			} `json:"FEATURES"`
+		} `json:"RESOLVED_ENTITY"`
+	}{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	for _, featureType := range []string{"NAME", "PHONE"} {
+		features := response.ResolvedEntity.Features[featureType]
+		require.Len(test, features, 1)
+
+		feature, err := repo.GetFeature(ctx, features[0].LibFeatID)
+		require.NoError(test, err)
+		assert.Contains(test, feature, `"FTYPE_CODE":"`+featureType+`"`)
+	}
+}
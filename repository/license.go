@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A license is the part of a GetLicense document the repository enforces.
+type license struct {
+	expiry      time.Time // The first instant after expireDate. If zero, the license does not expire.
+	expireDate  string
+	recordLimit *int64 // If nil, there is no limit.
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method SetLicense sets the license the repository enforces.
+
+Once the day after expireDate has begun, by the time of Clock, every engine and diagnostic call
+backed by the repository fails with a license error.
+Once recordLimit records are loaded, adding another record fails with a license error;
+replacing a loaded record is still allowed. A recordLimit of 0 allows no record at all.
+
+Input
+  - licenseDocument: A GetLicense document. If empty, no license is enforced.
+*/
+func (repo *Repository) SetLicense(licenseDocument string) error {
+	aLicense := license{}
+
+	if len(licenseDocument) > 0 {
+		parsed := struct {
+			ExpireDate  string `json:"expireDate"`
+			RecordLimit *int64 `json:"recordLimit"`
+		}{}
+
+		err := json.Unmarshal([]byte(licenseDocument), &parsed)
+		if err != nil {
+			return newError(2, "Invalid license: %s", licenseDocument)
+		}
+
+		if len(parsed.ExpireDate) > 0 {
+			expireDate, parseErr := time.Parse(time.DateOnly, parsed.ExpireDate)
+			if parseErr != nil {
+				return newError(2, "Invalid license expireDate: %s", parsed.ExpireDate)
+			}
+
+			aLicense.expiry = expireDate.AddDate(0, 0, 1)
+		}
+
+		aLicense.expireDate = parsed.ExpireDate
+		aLicense.recordLimit = parsed.RecordLimit
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.license = aLicense
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Fail once the license has expired.
+func (repo *Repository) checkLicense() error {
+	if !repo.license.expiry.IsZero() && !repo.now().Before(repo.license.expiry) {
+		return newError(999, "License has expired. expireDate: %s", repo.license.expireDate)
+	}
+
+	return nil
+}
+
+// Fail if adding a new record would exceed the record limit of the license.
+func (repo *Repository) checkRecordLimit(key recordKey) error {
+	if _, found := repo.records[key]; found || repo.license.recordLimit == nil {
+		return nil
+	}
+
+	if int64(len(repo.records)) >= *repo.license.recordLimit {
+		return newError(
+			9000,
+			"LIMIT: Maximum number of records ingested: %d. Contact Senzing to extend the license.",
+			*repo.license.recordLimit,
+		)
+	}
+
+	return nil
+}
+
+// Return Clock or, if nil, the default set by SetDefaults or the time of the host. The caller holds the mutex.
+func (repo *Repository) getClock() clock.Clock {
+	switch {
+	case repo.Clock != nil:
+		return repo.Clock
+	case repo.defaultClock != nil:
+		return repo.defaultClock
+	default:
+		return clock.Real{}
+	}
+}
+
+// Return the time of the clock of the repository. The caller holds the mutex.
+func (repo *Repository) now() time.Time {
+	return repo.getClock().Now()
+}
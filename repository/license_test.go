@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_SetLicense_recordLimit(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	require.NoError(test, repo.SetLicense(`{"expireDate":"2099-12-31","recordLimit":2}`))
+
+	for _, recordID := range []string{"R1", "R2"} {
+		addRecords(ctx, test, repo, record.Record{
+			DataSource: "CUSTOMERS",
+			ID:         recordID,
+			JSON:       fmt.Sprintf(`{"NAME_FULL": "ROBERT SMITH %s"}`, recordID),
+		})
+	}
+
+	_, err := repo.AddRecord(ctx, "CUSTOMERS", "R3", `{"NAME_FULL": "JANE DOE"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+
+	// Deleting a record never adds one, so it is not limited, even if the record is unknown.
+	_, err = repo.DeleteRecord(ctx, "CUSTOMERS", "R9", senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	// Replacing a loaded record does not count against the limit.
+	_, err = repo.AddRecord(ctx, "CUSTOMERS", "R2", `{"NAME_FULL": "JANE DOE"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	require.NoError(test, repo.SetLicense(""))
+	_, err = repo.AddRecord(ctx, "CUSTOMERS", "R3", `{"NAME_FULL": "JANE DOE"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	// Without a recordLimit there is no limit, while a recordLimit of 0 allows no record at all.
+	require.NoError(test, repo.SetLicense(`{"expireDate":"2099-12-31"}`))
+	_, err = repo.AddRecord(ctx, "CUSTOMERS", "R4", `{"NAME_FULL": "JOHN DOE"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	emptyRepo := &repository.Repository{}
+	require.NoError(test, emptyRepo.SetLicense(`{"expireDate":"2099-12-31","recordLimit":0}`))
+	_, err = emptyRepo.AddRecord(ctx, "CUSTOMERS", "R1", `{"NAME_FULL": "JOHN DOE"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+}
+
+func TestRepository_SetLicense_expireDate(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aClock := clock.NewFake(time.Date(2024, time.January, 31, 23, 59, 0, 0, time.UTC))
+	repo := &repository.Repository{Clock: aClock}
+	require.NoError(test, repo.SetLicense(`{"expireDate":"2024-01-31","recordLimit":50000}`))
+
+	aRecord := record.Record{DataSource: "CUSTOMERS", ID: "R1", JSON: `{"NAME_FULL": "ROBERT SMITH"}`}
+	addRecords(ctx, test, repo, aRecord)
+
+	aClock.Advance(time.Minute)
+
+	_, err := repo.GetRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+	_, err = repo.AddRecord(ctx, "CUSTOMERS", "R2", `{"NAME_FULL": "JANE DOE"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+	_, err = repo.GetStats(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+
+	// Diagnostic calls are licensed too.
+	_, err = repo.CheckRepositoryPerformance(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+	_, err = repo.GetFeature(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+	_, err = repo.GetRepositoryInfo(ctx, `{"SQL": {"CONNECTION": "sqlite3://na:na@/tmp/sqlite/G2C.db"}}`)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+	require.ErrorIs(test, repo.PurgeRepository(ctx), szerror.ErrSzLicense)
+}
+
+func TestRepository_SetLicense_badLicense(test *testing.T) {
+	test.Parallel()
+	repo := &repository.Repository{}
+
+	for _, license := range []string{badRecordDefinition, `{"expireDate":"31/01/2024"}`} {
+		require.ErrorIs(test, repo.SetLicense(license), szerror.ErrSzBadInput)
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+InterestRule describes which entities near a focus entity are interesting.
+
+An entity within MaxDegrees of the focus entity is interesting when it has a record from
+one of DataSources, if any are listed, and shares a feature of one of SharedFeatures with
+the focus entity, if any are listed.
+*/
+type InterestRule struct {
+	DataSources    []string // Data sources of which the entity must have a record.
+	Flag           string   // The flag reported in FLAGS for entities the rule finds.
+	MaxDegrees     int64    // How many relationships away to look. Values below 1 look 1 degree away.
+	SharedFeatures []string // Feature types, such as "PHONE", of which the entity must share a feature.
+}
+
+/*
+Rule describes when the simulated resolver resolves or relates two records.
+
+A rule fires when every feature type in Required agrees between the two records
+and, if AnyOf is not empty, at least one feature type in AnyOf agrees.
+Rules are evaluated in order; the first rule that fires decides the match level.
+*/
+type Rule struct {
+	AnyOf      []string // Feature types of which at least one must agree.
+	Code       string   // The ERRULE_CODE reported for the decision.
+	MatchLevel string   // A MatchLevelXxx constant, e.g. MatchLevelResolved.
+	Required   []string // Feature types that must all agree.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Match levels reported in MATCH_LEVEL_CODE.
+const (
+	MatchLevelDisclosed       = "DISCLOSED"
+	MatchLevelNameOnly        = "NAME_ONLY"
+	MatchLevelPossiblyRelated = "POSSIBLY_RELATED"
+	MatchLevelPossiblySame    = "POSSIBLY_SAME"
+	MatchLevelResolved        = "RESOLVED"
+)
+
+const (
+	defaultSearchProfile = "SEARCH"
+	firstEntityID        = int64(100001)
+	jsonLineEnd          = "\n"
+	maxEntityIDGap       = int64(10) // If Random is set, entity IDs are up to this far apart.
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+/*
+Function DefaultRules returns the rules used when [Repository.Rules] is empty.
+
+Output
+  - A new list of rules, ordered from strongest to weakest.
+*/
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Code:       "SF1_CNAME",
+			MatchLevel: MatchLevelResolved,
+			Required:   []string{"NAME"},
+			AnyOf:      []string{"SSN", "DRLIC", "PASSPORT", "NATIONAL_ID", "TAX_ID"},
+		},
+		{
+			Code:       "CNAME_CFF_CEXCL",
+			MatchLevel: MatchLevelResolved,
+			Required:   []string{"NAME", "DOB"},
+			AnyOf:      []string{"ADDRESS", "PHONE", "EMAIL"},
+		},
+		{
+			Code:       "CNAME_CFF",
+			MatchLevel: MatchLevelPossiblySame,
+			Required:   []string{"NAME"},
+			AnyOf:      []string{"DOB", "ADDRESS", "PHONE", "EMAIL"},
+		},
+		{
+			Code:       "SFF",
+			MatchLevel: MatchLevelPossiblyRelated,
+			AnyOf:      []string{"ADDRESS", "PHONE", "EMAIL", "WEBSITE"},
+		},
+		{
+			Code:       "CNAME",
+			MatchLevel: MatchLevelNameOnly,
+			Required:   []string{"NAME"},
+		},
+	}
+}
+
+/*
+Function DefaultSearchProfiles returns the search profiles used when [Repository.SearchProfiles] is empty.
+
+The "SEARCH" profile reports any agreeing name or contact feature as at least POSSIBLY_RELATED.
+The "INGEST" profile scores candidates with the resolution rules.
+
+Output
+  - A new map of search profile names to rules, each ordered from strongest to weakest.
+*/
+func DefaultSearchProfiles() map[string][]Rule {
+	return map[string][]Rule{
+		"INGEST": DefaultRules(),
+		"SEARCH": {
+			{
+				Code:       "SF1_CNAME",
+				MatchLevel: MatchLevelResolved,
+				Required:   []string{"NAME"},
+				AnyOf:      []string{"SSN", "DRLIC", "PASSPORT", "NATIONAL_ID", "TAX_ID"},
+			},
+			{
+				Code:       "CNAME_CFF_CEXCL",
+				MatchLevel: MatchLevelResolved,
+				Required:   []string{"NAME", "DOB"},
+				AnyOf:      []string{"ADDRESS", "PHONE", "EMAIL"},
+			},
+			{
+				Code:       "CNAME_CFF",
+				MatchLevel: MatchLevelPossiblySame,
+				Required:   []string{"NAME"},
+				AnyOf: []string{
+					"DOB", "ADDRESS", "PHONE", "EMAIL", "SSN", "DRLIC", "PASSPORT", "NATIONAL_ID", "TAX_ID",
+				},
+			},
+			{
+				Code:       "SNAME_SFF",
+				MatchLevel: MatchLevelPossiblyRelated,
+				AnyOf:      []string{"NAME", "ADDRESS", "PHONE", "EMAIL", "WEBSITE", "SSN", "DRLIC", "PASSPORT"},
+			},
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type performanceDocument struct {
+	NumRecordsInserted int64 `json:"numRecordsInserted"`
+	InsertTime         int64 `json:"insertTime"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Rows kept in the scratch table of CheckRepositoryPerformance; later inserts overwrite the oldest rows.
+const performanceTableSize = 1024
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CheckRepositoryPerformance inserts synthetic rows into a scratch table for secondsToRun seconds.
+
+Each insert waits InsertLatency, if set, and then takes the repository lock,
+as it would contend for the native database.
+The test runs by the time of Clock, so with a fake clock it runs until the clock is advanced past secondsToRun.
+The scratch table is dropped afterwards, so the test is non-destructive.
+If ctx is done first, the test stops early: the rows inserted so far are reported along with the error of ctx.
+
+Input
+  - ctx: A context to control lifecycle.
+  - secondsToRun: Duration of the test in seconds.
+
+Output
+  - A JSON document with the number of rows inserted and the elapsed time in milliseconds.
+*/
+func (repo *Repository) CheckRepositoryPerformance(ctx context.Context, secondsToRun int) (string, error) {
+	if secondsToRun < 0 {
+		return "", newError(2, "Invalid value of secondsToRun '%d'", secondsToRun)
+	}
+
+	aClock, err := repo.checkPerformanceLicense()
+	if err != nil {
+		return "", err
+	}
+
+	start := aClock.Now()
+	deadline := start.Add(time.Duration(secondsToRun) * time.Second)
+	scratchTable := make([]string, performanceTableSize)
+	inserted := int64(0)
+
+	for aClock.Now().Before(deadline) {
+		err = repo.insertPerformanceRow(ctx, scratchTable, inserted+1)
+		if err != nil {
+			break
+		}
+
+		inserted++
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	repo.observe("checkRepositoryPerformance", start)
+
+	result, marshalErr := marshal(performanceDocument{
+		NumRecordsInserted: inserted,
+		InsertTime:         aClock.Now().Sub(start).Milliseconds(),
+	})
+	if marshalErr != nil {
+		return "", marshalErr
+	}
+
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the clock the performance test runs by, failing if the license has expired.
+func (repo *Repository) checkPerformanceLicense() (clock.Clock, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+
+	err := repo.checkLicense()
+	if err != nil {
+		repo.observe("checkRepositoryPerformance", repo.now())
+
+		return nil, err
+	}
+
+	return repo.getClock(), nil
+}
+
+// Insert one synthetic row, unless ctx is done before the insert completes.
+func (repo *Repository) insertPerformanceRow(ctx context.Context, scratchTable []string, rowID int64) error {
+	if repo.InsertLatency > 0 {
+		timer := time.NewTimer(repo.InsertLatency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	} else if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	scratchTable[rowID%performanceTableSize] = fmt.Sprintf("performance test row %d", rowID)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type performanceResponse struct {
+	NumRecordsInserted int64 `json:"numRecordsInserted"`
+	InsertTime         int64 `json:"insertTime"`
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_CheckRepositoryPerformance(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{InsertLatency: 100 * time.Millisecond}
+
+	actual, err := repo.CheckRepositoryPerformance(ctx, 1)
+	require.NoError(test, err)
+
+	response := getPerformance(test, actual)
+	assert.InDelta(test, 10, response.NumRecordsInserted, 1)
+	assert.GreaterOrEqual(test, response.InsertTime, int64(1000))
+}
+
+func TestRepository_CheckRepositoryPerformance_canceled(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithTimeout(test.Context(), 50*time.Millisecond)
+
+	defer cancel()
+
+	repo := &repository.Repository{InsertLatency: 20 * time.Millisecond}
+
+	actual, err := repo.CheckRepositoryPerformance(ctx, 10)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+
+	response := getPerformance(test, actual)
+	assert.InDelta(test, 2, response.NumRecordsInserted, 1)
+	assert.Less(test, response.InsertTime, int64(1000))
+}
+
+func TestRepository_CheckRepositoryPerformance_clock(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aClock := clock.NewFake(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	repo := &repository.Repository{Clock: aClock, InsertLatency: time.Millisecond}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		aClock.Advance(1500 * time.Millisecond)
+	}()
+
+	actual, err := repo.CheckRepositoryPerformance(ctx, 1)
+	require.NoError(test, err)
+
+	response := getPerformance(test, actual)
+	assert.Positive(test, response.NumRecordsInserted)
+	assert.Equal(test, int64(1500), response.InsertTime)
+}
+
+func TestRepository_CheckRepositoryPerformance_expiredLicense(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{Clock: clock.NewFake(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC))}
+	require.NoError(test, repo.SetLicense(`{"expireDate":"2099-12-31"}`))
+
+	actual, err := repo.CheckRepositoryPerformance(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+	assert.Empty(test, actual)
+}
+
+func TestRepository_CheckRepositoryPerformance_badSecondsToRun(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+
+	actual, err := repo.CheckRepositoryPerformance(ctx, 0)
+	require.NoError(test, err)
+	assert.Zero(test, getPerformance(test, actual).NumRecordsInserted)
+
+	_, err = repo.CheckRepositoryPerformance(ctx, -1)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getPerformance(test *testing.T, actual string) *performanceResponse {
+	test.Helper()
+
+	response := &performanceResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	return response
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method PurgeRepository deletes all records, disclosed relationships, entities, library features, redo records,
+export handles and statistics.
+
+As with the native call, the configuration is kept: the resolution, search and interest rules are unchanged.
+Entity and internal IDs start again from the beginning.
+If GuardPurge is true and AllowPurge is not, the repository is left untouched and a bad-input error is returned.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (repo *Repository) PurgeRepository(ctx context.Context) error {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if err := repo.checkLicense(); err != nil {
+		return err
+	}
+
+	if repo.GuardPurge && !repo.AllowPurge {
+		return newError(2, "PurgeRepository is disabled; set AllowPurge to enable it")
+	}
+
+	repo.deferredDeletes = nil
+	repo.disclosures = nil
+	repo.entities = nil
+	repo.exports = nil
+	repo.lastEntityID = 0
+	repo.lastExportHandle = 0
+	repo.lastInternalID = 0
+	repo.libraryFeatureIDs = nil
+	repo.libraryFeatures = nil
+	repo.records = nil
+	repo.redoQueue = nil
+	repo.initialize()
+	defer repo.observe("purgeRepository", repo.now())
+
+	return nil
+}
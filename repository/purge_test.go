@@ -0,0 +1,85 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_PurgeRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	record1 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R1",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`,
+	}
+	record2 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R2",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789"}`,
+	}
+	addRecords(ctx, test, repo, record1, record2)
+	firstEntityID := getEntityID(test, repo, record1)
+
+	_, err := repo.DeleteRecord(ctx, record2.DataSource, record2.ID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	exportHandle, err := repo.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	err = repo.PurgeRepository(ctx)
+	require.NoError(test, err)
+
+	_, err = repo.GetRecord(ctx, record1.DataSource, record1.ID, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+
+	count, err := repo.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Zero(test, count)
+
+	_, err = repo.FetchNext(ctx, exportHandle)
+	require.Error(test, err)
+
+	response := getStats(test, repo)
+	assert.Zero(test, response.Workload.AddedRecords)
+	assert.Equal(
+		test,
+		map[string]int64{"countRedoRecords": 1, "fetchNext": 1, "getRecord": 1, "purgeRepository": 1},
+		response.Workload.APICalls,
+	)
+
+	addRecords(ctx, test, repo, record1)
+	assert.Equal(test, firstEntityID, getEntityID(test, repo, record1))
+}
+
+func TestRepository_PurgeRepository_guarded(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{GuardPurge: true}
+	aRecord := record.Record{DataSource: "CUSTOMERS", ID: "R1", JSON: `{"NAME_FULL": "ROBERT SMITH"}`}
+	addRecords(ctx, test, repo, aRecord)
+
+	err := repo.PurgeRepository(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = repo.GetRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	repo.AllowPurge = true
+	err = repo.PurgeRepository(ctx)
+	require.NoError(test, err)
+
+	_, err = repo.GetRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
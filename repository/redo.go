@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// A redoDocument is a redo record: a request to re-resolve the entity of a record.
+type redoDocument struct {
+	Reason          string `json:"REASON"`
+	DataSource      string `json:"DATA_SOURCE"`
+	RecordID        string `json:"RECORD_ID"`
+	ReevalIteration int    `json:"REEVAL_ITERATION"`
+	DsrcAction      string `json:"DSRC_ACTION"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	redoActionAdd            = "A"
+	redoActionDeferredDelete = "X"
+	redoReasonAmbiguous      = "ambiguous resolution"
+	redoReasonDeferredDelete = "deferred delete"
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method CountRedoRecords returns the number of redo records waiting to be processed.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The depth of the redo queue.
+*/
+func (repo *Repository) CountRedoRecords(ctx context.Context) (int64, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("countRedoRecords", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return 0, err
+	}
+
+	return int64(len(repo.redoQueue)), nil
+}
+
+/*
+Method GetRedoRecord removes the oldest redo record from the redo queue.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - A redo record. If the queue is empty, an empty string is returned.
+*/
+func (repo *Repository) GetRedoRecord(ctx context.Context) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getRedoRecord", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	if len(repo.redoQueue) == 0 {
+		return "", nil
+	}
+
+	result := repo.redoQueue[0]
+	repo.redoQueue = repo.redoQueue[1:]
+
+	return result, nil
+}
+
+/*
+Method ProcessRedoRecord applies a redo record.
+
+A deferred delete re-resolves the entity the deleted record left.
+Any other redo record re-resolves the entity of the record it names.
+If neither entity exists anymore, then no changes are made.
+
+Input
+  - ctx: A context to control lifecycle.
+  - redoRecord: A redo record as returned by [Repository.GetRedoRecord].
+  - flags: Flags used to control information returned.
+
+Output
+  - A "with info" JSON document if flags include senzing.SzWithInfo; otherwise an empty string.
+*/
+func (repo *Repository) ProcessRedoRecord(ctx context.Context, redoRecord string, flags int64) (string, error) {
+	_ = ctx
+
+	aRedoDocument := &redoDocument{}
+
+	err := json.Unmarshal([]byte(redoRecord), aRedoDocument)
+	if err != nil {
+		return "", newError(2, "Invalid Message: %s", redoRecord)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("processRedoRecord", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	key := recordKey{dataSource: aRedoDocument.DataSource, recordID: aRedoDocument.RecordID}
+	affected := []int64{}
+
+	if entityID, found := repo.deferredDeletes[key]; found {
+		delete(repo.deferredDeletes, key)
+
+		if anEntity, found := repo.entities[entityID]; found {
+			affected = append(affected, repo.reevaluate(anEntity)...)
+		}
+	}
+
+	if aRecord, found := repo.records[key]; found {
+		affected = append(affected, repo.reevaluate(repo.entities[aRecord.entityID])...)
+	}
+
+	return repo.withInfo(aRedoDocument.DataSource, aRedoDocument.RecordID, affected, flags)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Report whether an entity is possibly the same as another entity.
+func (repo *Repository) isAmbiguous(anEntity *entity) bool {
+	for _, aRelationship := range repo.relationships(anEntity) {
+		if aRelationship.match.matchLevel == MatchLevelPossiblySame {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (repo *Repository) queueRedo(reason string, key recordKey, action string) {
+	document, err := marshal(redoDocument{
+		Reason:          reason,
+		DataSource:      key.dataSource,
+		RecordID:        key.recordID,
+		ReevalIteration: 1,
+		DsrcAction:      action,
+	})
+	if err != nil {
+		return
+	}
+
+	repo.redoQueue = append(repo.redoQueue, document)
+	repo.stats.redoTriggers++
+}
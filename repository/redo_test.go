@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/go-helpers/truthset"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_CountRedoRecords(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	actual, err := repo.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), actual)
+}
+
+func TestRepository_GetRedoRecord_emptyQueue(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	actual, err := repo.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestRepository_ProcessRedoRecord_deferredDelete(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	duplicate := record.Record{DataSource: aRecord.DataSource, ID: "9001", JSON: aRecord.JSON}
+	addRecords(ctx, test, repo, aRecord, duplicate)
+	entityID := getEntityID(test, repo, aRecord)
+
+	_, err := repo.DeleteRecord(ctx, duplicate.DataSource, duplicate.ID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	count, err := repo.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), count)
+
+	redoRecord, err := repo.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"REASON":"deferred delete","DATA_SOURCE":"CUSTOMERS","RECORD_ID":"9001","REEVAL_ITERATION":1,"DSRC_ACTION":"X"}`,
+		redoRecord,
+	)
+
+	actual, err := repo.ProcessRedoRecord(ctx, redoRecord, senzing.SzWithInfo)
+	require.NoError(test, err)
+
+	response := &withInfoResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	require.Len(test, response.AffectedEntities, 1)
+	assert.Equal(test, entityID, response.AffectedEntities[0].EntityID)
+
+	count, err = repo.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), count)
+}
+
+func TestRepository_ProcessRedoRecord_redoAmbiguous(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	records := []record.Record{
+		{DataSource: "CUSTOMERS", ID: "9001", JSON: `{"NAME_FULL": "JOHN DOE", "DATE_OF_BIRTH": "1980-01-01"}`},
+		{DataSource: "CUSTOMERS", ID: "9002", JSON: `{"NAME_FULL": "JOHN DOE", "DATE_OF_BIRTH": "1980-01-01"}`},
+	}
+
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, records...)
+	count, err := repo.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), count)
+
+	repo = &repository.Repository{RedoAmbiguous: true}
+	addRecords(ctx, test, repo, records...)
+
+	processed := 0
+
+	for {
+		redoRecord, err := repo.GetRedoRecord(ctx)
+		require.NoError(test, err)
+
+		if len(redoRecord) == 0 {
+			break
+		}
+
+		_, err = repo.ProcessRedoRecord(ctx, redoRecord, senzing.SzWithoutInfo)
+		require.NoError(test, err)
+
+		processed++
+	}
+
+	assert.Equal(test, 1, processed)
+}
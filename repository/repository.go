@@ -0,0 +1,804 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+/*
+Repository is an in-memory stand-in for the records and entities of a Senzing repository.
+
+The zero value is an empty repository that resolves records with [DefaultRules].
+A Repository is safe for concurrent use by the clients sharing it.
+*/
+type Repository struct {
+	AllowPurge     bool              // If GuardPurge is true, PurgeRepository only succeeds when AllowPurge is true.
+	Clock          clock.Clock       // Time of the license and statistics. If nil, the time of the host.
+	GuardPurge     bool              // If true, PurgeRepository fails unless AllowPurge is true.
+	InsertLatency  time.Duration     // Simulated latency of each insert of CheckRepositoryPerformance.
+	InterestRules  []InterestRule    // Rules finding interesting entities. If empty, no entity is interesting.
+	Random         *random.Source    // If set, entity IDs are assigned with random gaps drawn from it.
+	RedoAmbiguous  bool              // If true, adding a record possibly the same as another entity queues a redo record.
+	Rules          []Rule            // Resolution rules, strongest first. If empty, DefaultRules() is used.
+	SearchProfiles map[string][]Rule // Scoring rules by search profile name. If empty, DefaultSearchProfiles() is used.
+
+	defaultClock      clock.Clock         // Used while Clock is nil. Set by SetDefaults.
+	defaultRandom     *random.Source      // Used while Random is nil. Set by SetDefaults.
+	deferredDeletes   map[recordKey]int64 // Entities left behind by deletes whose redo records are queued.
+	disclosures       []disclosure
+	entities          map[int64]*entity
+	exports           map[uintptr]*export
+	lastEntityID      int64
+	lastExportHandle  uintptr
+	lastInternalID    int64
+	libraryFeatureIDs map[string]int64 // LIB_FEAT_ID by feature library key.
+	license           license
+	libraryFeatures   []*libraryFeature // By LIB_FEAT_ID - 1.
+	mutex             sync.Mutex
+	records           map[recordKey]*record
+	redoQueue         []string
+	stats             statistics
+}
+
+// An entity is a set of records the resolver considers to be the same thing.
+type entity struct {
+	id         int64
+	recordKeys []recordKey      // In the order the records joined the entity.
+	steps      []resolutionStep // The merges that built the entity, in order.
+}
+
+// A record is a record definition as loaded into the repository.
+type record struct {
+	dataSource string
+	entityID   int64
+	features   []feature
+	internalID int64
+	jsonData   map[string]any
+	match      matchInfo // The decision that joined the record to its entity.
+	recordID   string
+	unmapped   map[string]any
+}
+
+type recordKey struct {
+	dataSource string
+	recordID   string
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method AddRecord loads a record and resolves it against the entities in the repository.
+
+A record with the same data source code and record ID is replaced.
+Adding a new record fails once the record limit of the license set by SetLicense is reached.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - recordDefinition: A JSON document containing the record.
+  - flags: Flags used to control information returned.
+
+Output
+  - A "with info" JSON document if flags include senzing.SzWithInfo; otherwise an empty string.
+*/
+func (repo *Repository) AddRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	recordDefinition string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	jsonData, err := parseRecordDefinition(recordDefinition)
+	if err != nil {
+		return "", err
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("addRecord", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	key := recordKey{dataSource: dataSourceCode, recordID: recordID}
+	affected := []int64{}
+
+	if err := repo.checkRecordLimit(key); err != nil {
+		return "", err
+	}
+
+	if _, found := repo.records[key]; found {
+		affected = append(affected, repo.removeRecord(key))
+	}
+
+	features, unmapped := extractFeatures(jsonData)
+	repo.lastInternalID++
+	aRecord := &record{
+		dataSource: dataSourceCode,
+		features:   features,
+		internalID: repo.lastInternalID,
+		jsonData:   jsonData,
+		recordID:   recordID,
+		unmapped:   unmapped,
+	}
+	repo.records[key] = aRecord
+	repo.addLibraryFeatures(features)
+	repo.stats.addedRecords++
+	affected = append(affected, repo.resolve(aRecord)...)
+
+	if repo.RedoAmbiguous && repo.isAmbiguous(repo.entities[aRecord.entityID]) {
+		repo.queueRedo(redoReasonAmbiguous, key, redoActionAdd)
+	}
+
+	return repo.withInfo(dataSourceCode, recordID, affected, flags)
+}
+
+/*
+Method DeleteRecord removes a record from the repository and from its entity.
+
+If records remain in the entity, their re-evaluation is deferred to a queued redo record.
+
+Is idempotent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A "with info" JSON document if flags include senzing.SzWithInfo; otherwise an empty string.
+*/
+func (repo *Repository) DeleteRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("deleteRecord", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	key := recordKey{dataSource: dataSourceCode, recordID: recordID}
+	affected := []int64{}
+
+	if _, found := repo.records[key]; found {
+		entityID := repo.removeRecord(key)
+		affected = append(affected, entityID)
+		repo.stats.deletedRecords++
+
+		if _, found := repo.entities[entityID]; found {
+			repo.deferredDeletes[key] = entityID
+			repo.queueRedo(redoReasonDeferredDelete, key, redoActionDeferredDelete)
+		}
+	}
+
+	return repo.withInfo(dataSourceCode, recordID, affected, flags)
+}
+
+/*
+Method GetEntityByEntityID describes an entity.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID: The unique identifier of an entity.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags.
+*/
+func (repo *Repository) GetEntityByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getEntityByEntityID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	anEntity, err := repo.getEntity(entityID)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(repo.entityDocument(anEntity, flags))
+}
+
+/*
+Method GetEntityByRecordID describes the entity containing a record.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags.
+*/
+func (repo *Repository) GetEntityByRecordID(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getEntityByRecordID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	aRecord, err := repo.getRecord(dataSourceCode, recordID)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(repo.entityDocument(repo.entities[aRecord.entityID], flags))
+}
+
+/*
+Method GetRecord describes a record.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document shaped by the flags.
+*/
+func (repo *Repository) GetRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getRecord", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	aRecord, err := repo.getRecord(dataSourceCode, recordID)
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(repo.recordDocument(aRecord, flags))
+}
+
+/*
+Method GetRecordPreview describes the features a record would have if it were loaded.
+
+The repository is not changed.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordDefinition: A JSON document containing the record.
+  - flags: Flags used to control information returned.
+    senzing.SzEntityIncludeRecordFeatureDetails requests the FEATURES section.
+
+Output
+  - A JSON document shaped by the flags.
+*/
+func (repo *Repository) GetRecordPreview(ctx context.Context, recordDefinition string, flags int64) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getRecordPreview", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	jsonData, err := parseRecordDefinition(recordDefinition)
+	if err != nil {
+		return "", err
+	}
+
+	features, unmapped := extractFeatures(jsonData)
+	result := previewDocument{}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordFeatureDetails) {
+		result.Features = map[string][]previewFeatureDocument{}
+
+		for _, aFeature := range features {
+			result.Features[aFeature.featureType] = append(
+				result.Features[aFeature.featureType],
+				previewFeatureDocument{
+					LibFeatID:  repo.libraryFeatureID(aFeature),
+					FeatDesc:   aFeature.description(),
+					UsageType:  aFeature.usageType,
+					Attributes: aFeature.attributes,
+				},
+			)
+		}
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordJSONData) {
+		result.JSONData = jsonData
+	}
+
+	if hasFlag(flags, senzing.SzEntityIncludeRecordUnmappedData) {
+		result.UnmappedData = unmapped
+	}
+
+	return marshal(result)
+}
+
+/*
+Method GetVirtualEntityByRecordID describes a hypothetical entity built from a set of records.
+
+The records are merged as they are, whatever the resolver decided for them.
+The entity ID is the lowest entity ID of the records. Virtual entities do not have relationships.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordKeys: A JSON document listing records to include in the hypothetical entity.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document.
+*/
+func (repo *Repository) GetVirtualEntityByRecordID(
+	ctx context.Context,
+	recordKeys string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("getVirtualEntityByRecordID", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	keys, err := parseRecordKeys(recordKeys)
+	if err != nil {
+		return "", err
+	}
+
+	if len(keys) == 0 {
+		return "", newError(2, "No records in '%s'", recordKeys)
+	}
+
+	anEntity := &entity{}
+
+	for _, key := range keys {
+		aRecord, recordErr := repo.getRecord(key.dataSource, key.recordID)
+		if recordErr != nil {
+			return "", recordErr
+		}
+
+		if slices.Contains(anEntity.recordKeys, key) {
+			continue
+		}
+
+		if anEntity.id == 0 || aRecord.entityID < anEntity.id {
+			anEntity.id = aRecord.entityID
+		}
+
+		anEntity.recordKeys = append(anEntity.recordKeys, key)
+	}
+
+	return marshal(entityDocument{
+		ResolvedEntity: repo.resolvedEntityDocument(anEntity, flags),
+	})
+}
+
+/*
+Method ReevaluateEntity re-resolves the records of an entity under the current rules.
+Records that no longer resolve together are split off, and entities that now resolve are merged.
+
+If the entity is not found, then no changes are made.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID: The unique identifier of an entity.
+  - flags: Flags used to control information returned.
+
+Output
+  - A "with info" JSON document if flags include senzing.SzWithInfo; otherwise an empty string.
+    The DATA_SOURCE and RECORD_ID identify the first record of the entity.
+*/
+func (repo *Repository) ReevaluateEntity(ctx context.Context, entityID int64, flags int64) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("reevaluateEntity", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	anEntity, found := repo.entities[entityID]
+	if !found {
+		return repo.withInfo("", "", []int64{}, flags)
+	}
+
+	key := anEntity.recordKeys[0]
+
+	return repo.withInfo(key.dataSource, key.recordID, repo.reevaluate(anEntity), flags)
+}
+
+/*
+Method ReevaluateRecord re-resolves the entity containing a record under the current rules.
+Records that no longer resolve together are split off, and entities that now resolve are merged.
+
+If the record is not found, then no changes are made.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A "with info" JSON document if flags include senzing.SzWithInfo; otherwise an empty string.
+*/
+func (repo *Repository) ReevaluateRecord(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("reevaluateRecord", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	affected := []int64{}
+
+	if aRecord, found := repo.records[recordKey{dataSource: dataSourceCode, recordID: recordID}]; found {
+		affected = repo.reevaluate(repo.entities[aRecord.entityID])
+	}
+
+	return repo.withInfo(dataSourceCode, recordID, affected, flags)
+}
+
+/*
+Method SetDefaults sets the clock and random source the repository uses while Clock or Random is nil,
+e.g. those of a factory sharing the repository. Clock and Random themselves are left unchanged.
+
+Input
+  - aClock: The clock used while Clock is nil. If nil, the time of the host.
+  - source: The source of entity ID gaps while Random is nil. If nil, entity IDs have no gaps.
+*/
+func (repo *Repository) SetDefaults(aClock clock.Clock, source *random.Source) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.defaultClock = aClock
+	repo.defaultRandom = source
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Create maps on first use so that the zero value is ready to use.  Caller must hold the mutex.
+func (repo *Repository) initialize() {
+	if repo.records != nil {
+		return
+	}
+
+	repo.deferredDeletes = map[recordKey]int64{}
+	repo.entities = map[int64]*entity{}
+	repo.exports = map[uintptr]*export{}
+	repo.libraryFeatureIDs = map[string]int64{}
+	repo.records = map[recordKey]*record{}
+	repo.stats = statistics{resetTime: repo.now()}
+}
+
+func (repo *Repository) entityIDs() []int64 {
+	result := make([]int64, 0, len(repo.entities))
+	for entityID := range repo.entities {
+		result = append(result, entityID)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+func (repo *Repository) getEntity(entityID int64) (*entity, error) {
+	anEntity, found := repo.entities[entityID]
+	if !found {
+		return nil, newError(37, "Unknown resolved entity value '%d'", entityID)
+	}
+
+	return anEntity, nil
+}
+
+func (repo *Repository) getRecord(dataSourceCode string, recordID string) (*record, error) {
+	aRecord, found := repo.records[recordKey{dataSource: dataSourceCode, recordID: recordID}]
+	if !found {
+		return nil, newError(33, "Unknown record: dsrc[%s], record[%s]", dataSourceCode, recordID)
+	}
+
+	return aRecord, nil
+}
+
+// Return Random or, if nil, the default set by SetDefaults. The caller holds the mutex.
+func (repo *Repository) getRandom() *random.Source {
+	if repo.Random == nil {
+		return repo.defaultRandom
+	}
+
+	return repo.Random
+}
+
+func (repo *Repository) newEntity(aRecord *record) *entity {
+	if repo.lastEntityID == 0 {
+		repo.lastEntityID = firstEntityID - 1
+	}
+
+	repo.lastEntityID++
+	if source := repo.getRandom(); source != nil {
+		repo.lastEntityID += source.Int64N(maxEntityIDGap)
+	}
+
+	anEntity := &entity{
+		id:         repo.lastEntityID,
+		recordKeys: []recordKey{aRecord.key()},
+	}
+	repo.entities[anEntity.id] = anEntity
+	aRecord.entityID = anEntity.id
+	aRecord.match = matchInfo{}
+
+	return anEntity
+}
+
+/*
+Method reevaluate re-resolves the records of an entity under the current rules.
+
+The first record stays in the entity; the others are resolved again, in the order
+they joined it, against every entity of the repository. Records that no longer
+resolve to the entity move to other or new entities. Finally, the entity absorbs
+any other entity it now resolves to.
+
+Output
+  - The IDs of the entities created, changed, or merged away.
+*/
+func (repo *Repository) reevaluate(anEntity *entity) []int64 {
+	repo.stats.reevaluations++
+	result := []int64{anEntity.id}
+	keys := anEntity.recordKeys
+	first := repo.records[keys[0]]
+	first.match = matchInfo{}
+	anEntity.recordKeys = []recordKey{first.key()}
+	anEntity.steps = []resolutionStep{}
+
+	for _, key := range keys[1:] {
+		result = append(result, repo.resolve(repo.records[key])...)
+	}
+
+	if _, exists := repo.entities[anEntity.id]; !exists {
+		return result
+	}
+
+	for _, entityID := range repo.entityIDs() {
+		if entityID == anEntity.id {
+			continue
+		}
+
+		decision, found := repo.compareEntities(anEntity, repo.entities[entityID])
+		if found && decision.matchLevel == MatchLevelResolved {
+			repo.merge(anEntity, repo.entities[entityID], decision)
+			result = append(result, entityID)
+		}
+	}
+
+	return result
+}
+
+/*
+Method merge moves the records of an entity into another and removes the emptied entity.
+
+Input
+  - target: The entity that remains.
+  - other: The entity merged away.
+  - decision: The decision that merges the entities.
+*/
+func (repo *Repository) merge(target *entity, other *entity, decision matchInfo) {
+	for _, key := range other.recordKeys {
+		repo.records[key].entityID = target.id
+	}
+
+	target.steps = append(target.steps, other.steps...)
+	target.steps = append(target.steps, resolutionStep{
+		inbound: slices.Clone(other.recordKeys),
+		match:   decision,
+		records: slices.Clone(target.recordKeys),
+	})
+	target.recordKeys = append(target.recordKeys, other.recordKeys...)
+	delete(repo.entities, other.id)
+}
+
+/*
+Method removeRecord removes a record from the repository.
+An entity left without records is removed.
+
+Output
+  - The ID of the entity the record belonged to.
+*/
+func (repo *Repository) removeRecord(key recordKey) int64 {
+	aRecord := repo.records[key]
+	delete(repo.records, key)
+
+	anEntity := repo.entities[aRecord.entityID]
+	anEntity.recordKeys = slices.DeleteFunc(anEntity.recordKeys, func(candidate recordKey) bool {
+		return candidate == key
+	})
+
+	if len(anEntity.recordKeys) == 0 {
+		delete(repo.entities, anEntity.id)
+	}
+
+	return anEntity.id
+}
+
+/*
+Method resolve places a record that is not yet part of an entity.
+
+The record joins the first entity it resolves to.
+If it resolves to several entities, they are merged into the first.
+
+Output
+  - The IDs of the entities created, changed, or merged away.
+*/
+func (repo *Repository) resolve(aRecord *record) []int64 {
+	var (
+		decisions = map[int64]matchInfo{}
+		matched   = []*entity{}
+	)
+
+	for _, entityID := range repo.entityIDs() {
+		anEntity := repo.entities[entityID]
+
+		decision, found := repo.compareRecordToEntity(aRecord, anEntity)
+		if found && decision.matchLevel == MatchLevelResolved {
+			matched = append(matched, anEntity)
+			decisions[entityID] = decision
+		}
+	}
+
+	if len(matched) == 0 {
+		return []int64{repo.newEntity(aRecord).id}
+	}
+
+	target := matched[0]
+	target.steps = append(target.steps, resolutionStep{
+		inbound: []recordKey{aRecord.key()},
+		match:   decisions[target.id],
+		records: slices.Clone(target.recordKeys),
+	})
+	target.recordKeys = append(target.recordKeys, aRecord.key())
+	aRecord.entityID = target.id
+	aRecord.match = decisions[target.id]
+	result := []int64{target.id}
+
+	for _, other := range matched[1:] {
+		repo.merge(target, other, decisions[other.id])
+		result = append(result, other.id)
+	}
+
+	return result
+}
+
+func (repo *Repository) compareRecordToEntity(aRecord *record, anEntity *entity) (matchInfo, bool) {
+	var (
+		best  matchInfo
+		found bool
+	)
+
+	for _, key := range anEntity.recordKeys {
+		candidate, fired := repo.compareRecords(aRecord, repo.records[key])
+		if fired && (!found || candidate.stronger(best)) {
+			best = candidate
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func (aRecord *record) key() recordKey {
+	return recordKey{dataSource: aRecord.dataSource, recordID: aRecord.recordID}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func marshal(document any) (string, error) {
+	result, err := json.Marshal(document)
+	if err != nil {
+		return "", newError(0, "Cannot build JSON document: %v", err)
+	}
+
+	return string(result), nil
+}
+
+/*
+Function newError returns an error typed the way the native Senzing binary would type the error code.
+
+Input
+  - code: The Senzing error code.
+  - format: The format string (think fmt.Sprintf()).
+  - args: values to be put into the format string.
+
+Output
+  - An error whose message is a JSON document with the native "SENZnnnn|message" as its reason.
+    Like the messages of the native SDK, it is JSON so that wraperror keeps the szerror types in the error chain.
+*/
+func newError(code int, format string, args ...any) error {
+	message, _ := json.Marshal(struct {
+		Reason string `json:"reason"`
+	}{
+		Reason: fmt.Sprintf("SENZ%04d|", code) + fmt.Sprintf(format, args...),
+	})
+
+	return szerror.New(code, string(message))
+}
+
+func parseRecordDefinition(recordDefinition string) (map[string]any, error) {
+	result := map[string]any{}
+
+	err := json.Unmarshal([]byte(recordDefinition), &result)
+	if err != nil || result == nil {
+		return nil, newError(2, "Invalid Message: %s", recordDefinition)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,597 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/go-helpers/truthset"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entityResponse struct {
+	RelatedEntities *[]struct {
+		EntityID       int64  `json:"ENTITY_ID"`
+		IsAmbiguous    int    `json:"IS_AMBIGUOUS"`
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	} `json:"RELATED_ENTITIES"`
+	ResolvedEntity struct {
+		EntityID   int64                     `json:"ENTITY_ID"`
+		EntityName string                    `json:"ENTITY_NAME"`
+		Features   map[string]any            `json:"FEATURES"`
+		Records    *[]map[string]interface{} `json:"RECORDS"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+type withInfoResponse struct {
+	AffectedEntities []struct {
+		EntityID int64 `json:"ENTITY_ID"`
+	} `json:"AFFECTED_ENTITIES"`
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+}
+
+const badRecordDefinition = "}{"
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_AddRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	actual, err := repo.AddRecord(ctx, aRecord.DataSource, aRecord.ID, aRecord.JSON, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestRepository_AddRecord_badRecordDefinition(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	_, err := repo.AddRecord(ctx, "CUSTOMERS", "1001", badRecordDefinition, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestRepository_AddRecord_withInfo(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	actual, err := repo.AddRecord(ctx, aRecord.DataSource, aRecord.ID, aRecord.JSON, senzing.SzWithInfo)
+	require.NoError(test, err)
+
+	response := &withInfoResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Equal(test, aRecord.DataSource, response.DataSource)
+	assert.Equal(test, aRecord.ID, response.RecordID)
+	require.Len(test, response.AffectedEntities, 1)
+	assert.Equal(test, getEntityID(test, repo, aRecord), response.AffectedEntities[0].EntityID)
+}
+
+func TestRepository_AddRecord_resolves(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, truthset.CustomerRecords["1001"], truthset.CustomerRecords["1001"])
+
+	related := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "9001",
+		JSON: `{"NAME_FIRST": "ROBERT", "NAME_LAST": "SMITH", ` +
+			`"DATE_OF_BIRTH": "1978-12-11", "EMAIL_ADDRESS": "bsmith@work.com"}`,
+	}
+	addRecords(ctx, test, repo, related)
+	assert.Equal(
+		test,
+		getEntityID(test, repo, truthset.CustomerRecords["1001"]),
+		getEntityID(test, repo, related),
+	)
+}
+
+func TestRepository_AddRecord_random(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	records := []record.Record{
+		{DataSource: "CUSTOMERS", ID: "R1", JSON: `{"NAME_FULL": "ROBERT SMITH"}`},
+		{DataSource: "CUSTOMERS", ID: "R2", JSON: `{"NAME_FULL": "JANE DOE"}`},
+		{DataSource: "CUSTOMERS", ID: "R3", JSON: `{"NAME_FULL": "JOHN ROE"}`},
+	}
+	entityIDs := func(seed uint64) []int64 {
+		repo := &repository.Repository{Random: random.New(seed)}
+		addRecords(ctx, test, repo, records...)
+
+		result := []int64{}
+		for _, aRecord := range records {
+			result = append(result, getEntityID(test, repo, aRecord))
+		}
+
+		return result
+	}
+
+	actual := entityIDs(42)
+	assert.Equal(test, actual, entityIDs(42))
+	assert.Less(test, actual[0], actual[1])
+	assert.Less(test, actual[1], actual[2])
+	assert.LessOrEqual(test, actual[2]-actual[0], int64(30))
+}
+
+func TestRepository_DeleteRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	addRecords(ctx, test, repo, aRecord)
+	entityID := getEntityID(test, repo, aRecord)
+
+	actual, err := repo.DeleteRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":`+formatID(entityID)+`}],`+
+			`"INTERESTING_ENTITIES":{"ENTITIES":[]}}`,
+		actual,
+	)
+
+	_, err = repo.GetEntityByEntityID(ctx, entityID, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+
+	actual, err = repo.DeleteRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`,
+		actual,
+	)
+}
+
+func TestRepository_GetEntityByEntityID_flags(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, truthset.CustomerRecords["1001"], truthset.CustomerRecords["1002"])
+	entityID := getEntityID(test, repo, truthset.CustomerRecords["1001"])
+
+	testCases := []struct {
+		name            string
+		flags           int64
+		expectFeatures  bool
+		expectName      bool
+		expectRecords   bool
+		expectRelations bool
+	}{
+		{name: "no flags", flags: senzing.SzNoFlags},
+		{name: "entity name", flags: senzing.SzEntityIncludeEntityName, expectName: true},
+		{name: "features", flags: senzing.SzEntityIncludeRepresentativeFeatures, expectFeatures: true},
+		{name: "record data", flags: senzing.SzEntityIncludeRecordData, expectRecords: true},
+		{name: "record JSON data", flags: senzing.SzEntityIncludeRecordJSONData, expectRecords: true},
+		{name: "relations", flags: senzing.SzEntityIncludeAllRelations, expectRelations: true},
+		{
+			name:            "default",
+			flags:           senzing.SzEntityDefaultFlags,
+			expectFeatures:  true,
+			expectName:      true,
+			expectRecords:   true,
+			expectRelations: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := repo.GetEntityByEntityID(ctx, entityID, testCase.flags)
+			require.NoError(test, err)
+
+			response := &entityResponse{}
+			require.NoError(test, json.Unmarshal([]byte(actual), response))
+			assert.Equal(test, entityID, response.ResolvedEntity.EntityID)
+			assert.Equal(test, testCase.expectName, len(response.ResolvedEntity.EntityName) > 0)
+			assert.Equal(test, testCase.expectFeatures, len(response.ResolvedEntity.Features) > 0)
+			assert.Equal(test, testCase.expectRecords, response.ResolvedEntity.Records != nil)
+			assert.Equal(test, testCase.expectRelations, response.RelatedEntities != nil)
+		})
+	}
+}
+
+func TestRepository_GetEntityByEntityID_relatedEntities(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, truthset.CustomerRecords["1001"], truthset.CustomerRecords["1002"])
+	entityID1 := getEntityID(test, repo, truthset.CustomerRecords["1001"])
+	entityID2 := getEntityID(test, repo, truthset.CustomerRecords["1002"])
+	require.NotEqual(test, entityID1, entityID2)
+
+	flags := senzing.SzEntityIncludePossiblyRelatedRelations | senzing.SzEntityIncludeRelatedMatchingInfo
+	actual, err := repo.GetEntityByEntityID(ctx, entityID1, flags)
+	require.NoError(test, err)
+
+	response := &entityResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	require.NotNil(test, response.RelatedEntities)
+	require.Len(test, *response.RelatedEntities, 1)
+	assert.Equal(test, entityID2, (*response.RelatedEntities)[0].EntityID)
+	assert.Equal(test, repository.MatchLevelPossiblyRelated, (*response.RelatedEntities)[0].MatchLevelCode)
+	assert.Zero(test, (*response.RelatedEntities)[0].IsAmbiguous)
+
+	actual, err = repo.GetEntityByEntityID(ctx, entityID1, senzing.SzEntityIncludePossiblySameRelations)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"RESOLVED_ENTITY":{"ENTITY_ID":`+formatID(entityID1)+`},"RELATED_ENTITIES":[]}`, actual)
+}
+
+func TestRepository_GetEntityByEntityID_ambiguous(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	records := []record.Record{
+		{DataSource: "CUSTOMERS", ID: "9001", JSON: `{"NAME_FULL": "JOHN DOE", "DATE_OF_BIRTH": "1980-01-01"}`},
+		{DataSource: "CUSTOMERS", ID: "9002", JSON: `{"NAME_FULL": "JOHN DOE", "DATE_OF_BIRTH": "1980-01-01"}`},
+	}
+	addRecords(ctx, test, repo, records...)
+
+	flags := senzing.SzEntityIncludePossiblySameRelations | senzing.SzEntityIncludeRelatedMatchingInfo
+	actual, err := repo.GetEntityByEntityID(ctx, getEntityID(test, repo, records[0]), flags)
+	require.NoError(test, err)
+
+	response := &entityResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	require.NotNil(test, response.RelatedEntities)
+	require.Len(test, *response.RelatedEntities, 1)
+	assert.Equal(test, getEntityID(test, repo, records[1]), (*response.RelatedEntities)[0].EntityID)
+	assert.Equal(test, repository.MatchLevelPossiblySame, (*response.RelatedEntities)[0].MatchLevelCode)
+	assert.Equal(test, 1, (*response.RelatedEntities)[0].IsAmbiguous)
+}
+
+func TestRepository_GetEntityByRecordID_unknownRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	_, err := repo.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_GetRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	addRecords(ctx, test, repo, aRecord)
+
+	actual, err := repo.GetRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}`, actual)
+
+	actual, err = repo.GetRecord(ctx, aRecord.DataSource, aRecord.ID, senzing.SzEntityIncludeRecordJSONData)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","JSON_DATA":`+aRecord.JSON+`}`, actual)
+}
+
+func TestRepository_ExportJSONEntityReport(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, truthset.CustomerRecords["1001"], truthset.CustomerRecords["1009"])
+
+	exportHandle, err := repo.ExportJSONEntityReport(ctx, senzing.SzExportIncludeAllEntities)
+	require.NoError(test, err)
+
+	lines := fetchAll(ctx, test, repo, exportHandle)
+	assert.Len(test, lines, 2)
+	require.NoError(test, repo.CloseExportReport(ctx, exportHandle))
+
+	exportHandle, err = repo.ExportJSONEntityReport(ctx, senzing.SzExportIncludeMultiRecordEntities)
+	require.NoError(test, err)
+	assert.Empty(test, fetchAll(ctx, test, repo, exportHandle))
+	require.NoError(test, repo.CloseExportReport(ctx, exportHandle))
+
+	_, err = repo.FetchNext(ctx, exportHandle)
+	require.Error(test, err)
+}
+
+func TestRepository_ExportCsvEntityReport(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, truthset.CustomerRecords["1001"])
+
+	exportHandle, err := repo.ExportCsvEntityReport(
+		ctx,
+		"RESOLVED_ENTITY_ID,DATA_SOURCE,RECORD_ID",
+		senzing.SzExportIncludeAllEntities,
+	)
+	require.NoError(test, err)
+
+	lines := fetchAll(ctx, test, repo, exportHandle)
+	entityID := getEntityID(test, repo, truthset.CustomerRecords["1001"])
+	assert.Equal(
+		test,
+		[]string{"RESOLVED_ENTITY_ID,DATA_SOURCE,RECORD_ID\n", formatID(entityID) + ",CUSTOMERS,1001\n"},
+		lines,
+	)
+
+	_, err = repo.ExportCsvEntityReport(ctx, "BAD, CSV, COLUMN, LIST", senzing.SzExportIncludeAllEntities)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestRepository_GetRecordPreview(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	recordDefinition := `{"DATA_SOURCE": "CUSTOMERS", "PRIMARY_NAME_FIRST": "Robert", "PRIMARY_NAME_LAST": "Smith",` +
+		` "DATE_OF_BIRTH": "12/11/1978", "SSN_NUMBER": "123-45-6789", "COLOR": "blue"}`
+
+	actual, err := repo.GetRecordPreview(ctx, recordDefinition, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{}`, actual)
+
+	flags := senzing.SzRecordPreviewDefaultFlags | senzing.SzEntityIncludeRecordUnmappedData
+	actual, err = repo.GetRecordPreview(ctx, recordDefinition, flags)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"FEATURES":{`+
+			`"DOB":[{"FEAT_DESC":"12/11/1978","ATTRIBUTES":{"DATE_OF_BIRTH":"12/11/1978"}}],`+
+			`"NAME":[{"FEAT_DESC":"Robert Smith","USAGE_TYPE":"PRIMARY",`+
+			`"ATTRIBUTES":{"PRIMARY_NAME_FIRST":"Robert","PRIMARY_NAME_LAST":"Smith"}}],`+
+			`"SSN":[{"FEAT_DESC":"123-45-6789","ATTRIBUTES":{"SSN_NUMBER":"123-45-6789"}}]},`+
+			`"UNMAPPED_DATA":{"COLOR":"blue"}}`,
+		actual,
+	)
+
+	_, err = repo.GetRecordPreview(ctx, badRecordDefinition, flags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestRepository_GetVirtualEntityByRecordID(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1069"]
+	addRecords(ctx, test, repo, record1, record2)
+
+	entityID1 := getEntityID(test, repo, record1)
+	entityID2 := getEntityID(test, repo, record2)
+	require.NotEqual(test, entityID1, entityID2)
+
+	recordKeys := `{"RECORDS": [` +
+		`{"DATA_SOURCE": "` + record2.DataSource + `", "RECORD_ID": "` + record2.ID + `"},` +
+		`{"DATA_SOURCE": "` + record1.DataSource + `", "RECORD_ID": "` + record1.ID + `"}]}`
+
+	actual, err := repo.GetVirtualEntityByRecordID(ctx, recordKeys, senzing.SzVirtualEntityDefaultFlags)
+	require.NoError(test, err)
+
+	response := &entityResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Equal(test, min(entityID1, entityID2), response.ResolvedEntity.EntityID)
+	require.NotNil(test, response.ResolvedEntity.Records)
+	assert.Len(test, *response.ResolvedEntity.Records, 2)
+	assert.Nil(test, response.RelatedEntities)
+
+	_, err = repo.GetVirtualEntityByRecordID(
+		ctx,
+		`{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "Z"}]}`,
+		senzing.SzNoFlags,
+	)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+
+	_, err = repo.GetVirtualEntityByRecordID(ctx, `{"RECORDS": []}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = repo.GetVirtualEntityByRecordID(ctx, badRecordDefinition, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestRepository_ProcessRedoRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	addRecords(ctx, test, repo, aRecord)
+
+	redoRecord := `{"REASON":"LIBFEAT_ID[1] DISCLOSED","DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}`
+	actual, err := repo.ProcessRedoRecord(ctx, redoRecord, senzing.SzWithInfo)
+	require.NoError(test, err)
+
+	response := &withInfoResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Equal(test, aRecord.ID, response.RecordID)
+	require.Len(test, response.AffectedEntities, 1)
+	assert.Equal(test, getEntityID(test, repo, aRecord), response.AffectedEntities[0].EntityID)
+
+	_, err = repo.ProcessRedoRecord(ctx, badRecordDefinition, senzing.SzWithInfo)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestRepository_ReevaluateEntity(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	aRecord := truthset.CustomerRecords["1001"]
+	addRecords(ctx, test, repo, aRecord)
+	entityID := getEntityID(test, repo, aRecord)
+
+	actual, err := repo.ReevaluateEntity(ctx, entityID, senzing.SzWithInfo)
+	require.NoError(test, err)
+
+	response := &withInfoResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Equal(test, aRecord.DataSource, response.DataSource)
+	assert.Equal(test, aRecord.ID, response.RecordID)
+	require.Len(test, response.AffectedEntities, 1)
+
+	actual, err = repo.ReevaluateEntity(ctx, entityID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestRepository_ReevaluateEntity_split(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	record1 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R1",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`,
+	}
+	record2 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R2",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789"}`,
+	}
+	addRecords(ctx, test, repo, record1, record2)
+	entityID := getEntityID(test, repo, record1)
+	require.Equal(test, entityID, getEntityID(test, repo, record2))
+
+	repo.Rules = []repository.Rule{
+		{Code: "CNAME", MatchLevel: repository.MatchLevelNameOnly, Required: []string{"NAME"}},
+	}
+	actual, err := repo.ReevaluateEntity(ctx, entityID, senzing.SzWithInfo)
+	require.NoError(test, err)
+
+	splitEntityID := getEntityID(test, repo, record2)
+	assert.Equal(test, entityID, getEntityID(test, repo, record1))
+	assert.NotEqual(test, entityID, splitEntityID)
+	assert.Equal(test, []int64{entityID, splitEntityID}, affectedEntities(test, actual))
+}
+
+func TestRepository_ReevaluateRecord_merge(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{
+		Rules: []repository.Rule{
+			{Code: "CNAME", MatchLevel: repository.MatchLevelNameOnly, Required: []string{"NAME"}},
+		},
+	}
+	record1 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R1",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`,
+	}
+	record2 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R2",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789"}`,
+	}
+	addRecords(ctx, test, repo, record1, record2)
+	entityID1 := getEntityID(test, repo, record1)
+	entityID2 := getEntityID(test, repo, record2)
+	require.NotEqual(test, entityID1, entityID2)
+
+	repo.Rules = nil
+	actual, err := repo.ReevaluateRecord(ctx, record2.DataSource, record2.ID, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.Equal(test, entityID2, getEntityID(test, repo, record1))
+	assert.Equal(test, entityID2, getEntityID(test, repo, record2))
+	assert.Equal(test, []int64{entityID1, entityID2}, affectedEntities(test, actual))
+
+	_, err = repo.GetEntityByEntityID(ctx, entityID1, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_ReevaluateRecord_unknownRecord(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	actual, err := repo.ReevaluateRecord(ctx, "CUSTOMERS", "1001", senzing.SzWithInfo)
+	require.NoError(test, err)
+
+	response := &withInfoResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	assert.Empty(test, response.AffectedEntities)
+}
+
+func TestRepository_SetDefaults(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aRecord := record.Record{DataSource: "CUSTOMERS", ID: "R1", JSON: `{"NAME_FULL": "ROBERT SMITH"}`}
+	repo := &repository.Repository{}
+	require.NoError(test, repo.SetLicense(`{"expireDate":"2099-12-31"}`))
+	repo.SetDefaults(clock.NewFake(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)), random.New(42))
+	assert.Nil(test, repo.Clock)
+	assert.Nil(test, repo.Random)
+
+	_, err := repo.GetStats(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+
+	// The clock of the repository itself comes first.
+	repo.Clock = clock.NewFake(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	addRecords(ctx, test, repo, aRecord)
+
+	seeded := &repository.Repository{Random: random.New(42)}
+	addRecords(ctx, test, seeded, aRecord)
+	assert.Equal(test, getEntityID(test, seeded, aRecord), getEntityID(test, repo, aRecord))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func addRecords(ctx context.Context, test *testing.T, repo *repository.Repository, records ...record.Record) {
+	test.Helper()
+
+	for _, aRecord := range records {
+		_, err := repo.AddRecord(ctx, aRecord.DataSource, aRecord.ID, aRecord.JSON, senzing.SzWithoutInfo)
+		require.NoError(test, err)
+	}
+}
+
+func affectedEntities(test *testing.T, actual string) []int64 {
+	test.Helper()
+
+	response := &withInfoResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	result := []int64{}
+	for _, affectedEntity := range response.AffectedEntities {
+		result = append(result, affectedEntity.EntityID)
+	}
+
+	return result
+}
+
+func fetchAll(ctx context.Context, test *testing.T, repo *repository.Repository, exportHandle uintptr) []string {
+	test.Helper()
+
+	result := []string{}
+
+	for {
+		line, err := repo.FetchNext(ctx, exportHandle)
+		require.NoError(test, err)
+
+		if len(line) == 0 {
+			return result
+		}
+
+		result = append(result, line)
+	}
+}
+
+func formatID(entityID int64) string {
+	result, _ := json.Marshal(entityID)
+
+	return string(result)
+}
+
+func getEntityID(test *testing.T, repo *repository.Repository, aRecord record.Record) int64 {
+	test.Helper()
+
+	actual, err := repo.GetEntityByRecordID(test.Context(), aRecord.DataSource, aRecord.ID, senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	response := &entityResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	return response.ResolvedEntity.EntityID
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A matchInfo describes the outcome of comparing two records or entities.
+type matchInfo struct {
+	errRuleCode string
+	matchKey    string
+	matchLevel  string
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the rules in effect.
+func (repo *Repository) rules() []Rule {
+	if len(repo.Rules) == 0 {
+		return DefaultRules()
+	}
+
+	return repo.Rules
+}
+
+/*
+Method compareRecords applies the resolution rules to a pair of records.
+
+Input
+  - record1: The first record.
+  - record2: The second record.
+
+Output
+  - The decision of the first rule that fires.
+  - False if no rule fires.
+*/
+func (repo *Repository) compareRecords(record1 *record, record2 *record) (matchInfo, bool) {
+	return decide(repo.rules(), record1.features, record2.features)
+}
+
+/*
+Method compareEntities finds the strongest decision between any record of one entity
+and any record of another.
+
+Input
+  - entity1: The first entity.
+  - entity2: The second entity.
+
+Output
+  - The strongest decision.
+  - False if no rule fires for any pair of records.
+*/
+func (repo *Repository) compareEntities(entity1 *entity, entity2 *entity) (matchInfo, bool) {
+	var (
+		best  matchInfo
+		found bool
+	)
+
+	for _, key1 := range entity1.recordKeys {
+		for _, key2 := range entity2.recordKeys {
+			candidate, fired := repo.compareRecords(repo.records[key1], repo.records[key2])
+			if fired && (!found || candidate.stronger(best)) {
+				best = candidate
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// --- Rules ------------------------------------------------------------------
+
+func (rule Rule) fires(agreeing []string) bool {
+	for _, required := range rule.Required {
+		if !slices.Contains(agreeing, required) {
+			return false
+		}
+	}
+
+	if len(rule.AnyOf) == 0 {
+		return len(rule.Required) > 0
+	}
+
+	for _, anyOf := range rule.AnyOf {
+		if slices.Contains(agreeing, anyOf) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (aMatchInfo matchInfo) stronger(other matchInfo) bool {
+	rank := matchLevelRank(aMatchInfo.matchLevel)
+	otherRank := matchLevelRank(other.matchLevel)
+
+	if rank != otherRank {
+		return rank < otherRank
+	}
+
+	return strings.Count(aMatchInfo.matchKey, "+") > strings.Count(other.matchKey, "+")
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Function decide applies rules to two lists of features.
+
+Input
+  - rules: The rules, strongest first.
+  - features1: The features of one record.
+  - features2: The features of the other record.
+
+Output
+  - The decision of the first rule that fires.
+  - False if no rule fires.
+*/
+func decide(rules []Rule, features1 []feature, features2 []feature) (matchInfo, bool) {
+	agreeing := agreeingFeatureTypes(features1, features2)
+
+	for _, rule := range rules {
+		if rule.fires(agreeing) {
+			return matchInfo{
+				errRuleCode: rule.Code,
+				matchKey:    matchKey(agreeing),
+				matchLevel:  rule.MatchLevel,
+			}, true
+		}
+	}
+
+	return matchInfo{}, false
+}
+
+// Return the feature types, in canonical order, for which a feature of each list agrees.
+func agreeingFeatureTypes(features1 []feature, features2 []feature) []string {
+	result := []string{}
+
+	for _, featureType := range featureTypes {
+		if featuresAgree(features1, features2, featureType) {
+			result = append(result, featureType)
+		}
+	}
+
+	return result
+}
+
+func featuresAgree(features1 []feature, features2 []feature, featureType string) bool {
+	for _, feature1 := range features1 {
+		if feature1.featureType != featureType {
+			continue
+		}
+
+		for _, feature2 := range features2 {
+			if feature1.agrees(feature2) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchKey(featureTypes []string) string {
+	if len(featureTypes) == 0 {
+		return ""
+	}
+
+	return "+" + strings.Join(featureTypes, "+")
+}
+
+// Lower ranks are stronger, mirroring the native MATCH_LEVEL numbers.
+func matchLevelRank(matchLevel string) int {
+	switch matchLevel {
+	case MatchLevelResolved:
+		return 1
+	case MatchLevelPossiblySame:
+		return 2
+	case MatchLevelPossiblyRelated:
+		return 3
+	case MatchLevelNameOnly:
+		return 4
+	case MatchLevelDisclosed:
+		return 11
+	default:
+		return math.MaxInt
+	}
+}
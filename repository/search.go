@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type searchDocument struct {
+	ResolvedEntities []searchEntityDocument `json:"RESOLVED_ENTITIES"`
+}
+
+type searchEntityDocument struct {
+	MatchInfo matchingInfoDocument `json:"MATCH_INFO"`
+	Entity    entityDocument       `json:"ENTITY"`
+}
+
+// A searchResult is an entity found by a search and the decision that found it.
+type searchResult struct {
+	entity *entity
+	match  matchInfo
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Flags selecting search results by match level.
+var searchLevelFlags = map[int64]string{
+	senzing.SzSearchIncludeNameOnly:        MatchLevelNameOnly,
+	senzing.SzSearchIncludePossiblyRelated: MatchLevelPossiblyRelated,
+	senzing.SzSearchIncludePossiblySame:    MatchLevelPossiblySame,
+	senzing.SzSearchIncludeResolved:        MatchLevelResolved,
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method SearchByAttributes scores the entities of the repository against a set of attributes.
+
+Results are ordered by match level, strongest first, then by the number of agreeing features
+and finally by entity ID.
+
+Input
+  - ctx: A context to control lifecycle.
+  - attributes: A JSON document containing the attributes desired in the result set.
+  - searchProfile: The name of the search profile whose rules score the entities, in any case.
+    An empty string uses the "SEARCH" profile, that of DefaultSearchProfiles() if SearchProfiles has none.
+  - flags: Flags used to select match levels and to shape each entity document.
+    If no SzSearchIncludeXxx flag is set, all match levels are returned.
+
+Output
+  - A JSON document listing the entities found.
+*/
+func (repo *Repository) SearchByAttributes(
+	ctx context.Context,
+	attributes string,
+	searchProfile string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("searchByAttributes", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	results, err := repo.search(attributes, searchProfile)
+	if err != nil {
+		return "", err
+	}
+
+	matchLevels := []string{}
+
+	for flag, matchLevel := range searchLevelFlags {
+		if hasFlag(flags, flag) {
+			matchLevels = append(matchLevels, matchLevel)
+		}
+	}
+
+	result := searchDocument{
+		ResolvedEntities: []searchEntityDocument{},
+	}
+
+	for _, aSearchResult := range results {
+		if len(matchLevels) > 0 && !slices.Contains(matchLevels, aSearchResult.match.matchLevel) {
+			continue
+		}
+
+		result.ResolvedEntities = append(result.ResolvedEntities, searchEntityDocument{
+			MatchInfo: aSearchResult.match.document(),
+			Entity:    repo.entityDocument(aSearchResult.entity, flags),
+		})
+	}
+
+	return marshal(result)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the rules of a search profile, whose name is matched regardless of case.
+func (repo *Repository) searchProfile(searchProfile string) ([]Rule, error) {
+	profiles := repo.SearchProfiles
+	if len(profiles) == 0 {
+		profiles = DefaultSearchProfiles()
+	}
+
+	name := normalizeSearchProfile(searchProfile)
+	if len(name) == 0 {
+		name = defaultSearchProfile
+	}
+
+	if rules, found := profiles[name]; found {
+		return rules, nil
+	}
+
+	for _, profileName := range slices.Sorted(maps.Keys(profiles)) {
+		if normalizeSearchProfile(profileName) == name {
+			return profiles[profileName], nil
+		}
+	}
+
+	if name == defaultSearchProfile && len(normalizeSearchProfile(searchProfile)) == 0 {
+		return DefaultSearchProfiles()[defaultSearchProfile], nil
+	}
+
+	return nil, newError(88, "Unknown search profile value '%s'", searchProfile)
+}
+
+/*
+Method search finds the entities whose records agree with a set of attributes.
+
+Input
+  - attributes: A JSON document of record attributes.
+  - searchProfile: The name of the search profile.
+
+Output
+  - The entities found, best first.
+*/
+func (repo *Repository) search(attributes string, searchProfile string) ([]searchResult, error) {
+	jsonData, err := parseRecordDefinition(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := repo.searchProfile(searchProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	features, _ := extractFeatures(jsonData)
+	result := []searchResult{}
+
+	for _, entityID := range repo.entityIDs() {
+		anEntity := repo.entities[entityID]
+
+		var (
+			best  matchInfo
+			found bool
+		)
+
+		for _, key := range anEntity.recordKeys {
+			candidate, fired := decide(rules, features, repo.records[key].features)
+			if fired && (!found || candidate.stronger(best)) {
+				best = candidate
+				found = true
+			}
+		}
+
+		if found {
+			result = append(result, searchResult{entity: anEntity, match: best})
+		}
+	}
+
+	slices.SortStableFunc(result, func(result1 searchResult, result2 searchResult) int {
+		switch {
+		case result1.match.stronger(result2.match):
+			return -1
+		case result2.match.stronger(result1.match):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the canonical form of a search profile name.
+func normalizeSearchProfile(searchProfile string) string {
+	return strings.ToUpper(strings.TrimSpace(searchProfile))
+}
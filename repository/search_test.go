@@ -0,0 +1,165 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchResponse struct {
+	ResolvedEntities []struct {
+		Entity struct {
+			ResolvedEntity struct {
+				EntityID int64 `json:"ENTITY_ID"`
+			} `json:"RESOLVED_ENTITY"`
+		} `json:"ENTITY"`
+		MatchInfo struct {
+			ErruleCode     string `json:"ERRULE_CODE"`
+			MatchKey       string `json:"MATCH_KEY"`
+			MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+		} `json:"MATCH_INFO"`
+	} `json:"RESOLVED_ENTITIES"`
+}
+
+const searchAttributes = `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789", "PHONE_NUMBER": "702-555-1212"}`
+
+var searchRecords = []record.Record{
+	{DataSource: "CUSTOMERS", ID: "S1", JSON: `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`},
+	{DataSource: "CUSTOMERS", ID: "S2", JSON: `{"NAME_FULL": "ROBERT SMITH", "PHONE_NUMBER": "(702) 555-1212"}`},
+	{DataSource: "CUSTOMERS", ID: "S3", JSON: `{"NAME_FULL": "JANE DOE", "PHONE_NUMBER": "702-555-1212"}`},
+	{DataSource: "CUSTOMERS", ID: "S4", JSON: `{"NAME_FULL": "MARY JONES"}`},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_SearchByAttributes(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+
+	testCases := []struct {
+		name          string
+		searchProfile string
+		flags         int64
+		expected      []string // MATCH_LEVEL_CODE and MATCH_KEY of each result, in order.
+	}{
+		{
+			name:     "default profile",
+			flags:    senzing.SzSearchByAttributesDefaultFlags,
+			expected: []string{"RESOLVED+NAME+SSN", "POSSIBLY_SAME+NAME+PHONE", "POSSIBLY_RELATED+PHONE"},
+		},
+		{
+			name:          "INGEST profile",
+			searchProfile: "INGEST",
+			flags:         senzing.SzSearchByAttributesDefaultFlags,
+			expected:      []string{"RESOLVED+NAME+SSN", "POSSIBLY_SAME+NAME+PHONE", "POSSIBLY_RELATED+PHONE"},
+		},
+		{
+			name:     "no flags",
+			flags:    senzing.SzNoFlags,
+			expected: []string{"RESOLVED+NAME+SSN", "POSSIBLY_SAME+NAME+PHONE", "POSSIBLY_RELATED+PHONE"},
+		},
+		{
+			name:     "strong matches",
+			flags:    senzing.SzSearchByAttributesStrong,
+			expected: []string{"RESOLVED+NAME+SSN", "POSSIBLY_SAME+NAME+PHONE"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			repo := getSearchRepository(ctx, test)
+			actual, err := repo.SearchByAttributes(ctx, searchAttributes, testCase.searchProfile, testCase.flags)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, searchMatches(test, actual))
+		})
+	}
+}
+
+func TestRepository_SearchByAttributes_searchProfiles(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := getSearchRepository(ctx, test)
+	repo.SearchProfiles = map[string][]repository.Rule{
+		"IDS_ONLY": {{Code: "SF1", MatchLevel: repository.MatchLevelResolved, AnyOf: []string{"SSN"}}},
+	}
+
+	actual, err := repo.SearchByAttributes(ctx, searchAttributes, "IDS_ONLY", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"RESOLVED+NAME+SSN"}, searchMatches(test, actual))
+
+	_, err = repo.SearchByAttributes(ctx, searchAttributes, "SEARCH", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	// Without a SEARCH profile of its own, the repository searches with the default one.
+	actual, err = repo.SearchByAttributes(ctx, searchAttributes, "", senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	expected, err := getSearchRepository(ctx, test).SearchByAttributes(ctx, searchAttributes, "", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, searchMatches(test, expected), searchMatches(test, actual))
+}
+
+func TestRepository_SearchByAttributes_searchProfileCase(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := getSearchRepository(ctx, test)
+	repo.SearchProfiles = map[string][]repository.Rule{
+		"watchlist": {{Code: "SF1", MatchLevel: repository.MatchLevelResolved, AnyOf: []string{"SSN"}}},
+	}
+
+	for _, searchProfile := range []string{"watchlist", "WATCHLIST", " WatchList "} {
+		actual, err := repo.SearchByAttributes(ctx, searchAttributes, searchProfile, senzing.SzNoFlags)
+		require.NoError(test, err)
+		assert.Equal(test, []string{"RESOLVED+NAME+SSN"}, searchMatches(test, actual))
+	}
+}
+
+func TestRepository_SearchByAttributes_badInput(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := getSearchRepository(ctx, test)
+
+	_, err := repo.SearchByAttributes(ctx, "}{", "", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = repo.SearchByAttributes(ctx, searchAttributes, "}{", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getSearchRepository(ctx context.Context, test *testing.T) *repository.Repository {
+	test.Helper()
+
+	repo := &repository.Repository{}
+	addRecords(ctx, test, repo, searchRecords...)
+
+	return repo
+}
+
+func searchMatches(test *testing.T, actual string) []string {
+	test.Helper()
+
+	response := &searchResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	result := []string{}
+	for _, resolvedEntity := range response.ResolvedEntities {
+		result = append(result, resolvedEntity.MatchInfo.MatchLevelCode+resolvedEntity.MatchInfo.MatchKey)
+	}
+
+	return result
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// apiStatistics counts the calls to one method and buckets their latency.
+type apiStatistics struct {
+	count   int64
+	latency []int64 // Calls per entry of latencyBuckets; the last entry counts slower calls.
+}
+
+type latencyDocument map[string]int64
+
+// statistics are the workload counters reported, and reset, by GetStats.
+type statistics struct {
+	addedRecords   int64
+	apiCalls       map[string]*apiStatistics
+	deletedRecords int64
+	reevaluations  int64
+	redoTriggers   int64
+	resetTime      time.Time
+}
+
+type statsDocument struct {
+	Workload workloadDocument `json:"workload"`
+}
+
+type workloadDocument struct {
+	AbortedUnresolve    int64                      `json:"abortedUnresolve"`
+	ActualAmbiguousTest int64                      `json:"actualAmbiguousTest"`
+	AddedRecords        int64                      `json:"addedRecords"`
+	APICalls            map[string]int64           `json:"apiCalls"`
+	APILatency          map[string]latencyDocument `json:"apiLatency"`
+	DeletedRecords      int64                      `json:"deletedRecords"`
+	Duration            int64                      `json:"duration"`
+	Reevaluations       int64                      `json:"reevaluations"`
+	RedoTriggers        int64                      `json:"redoTriggers"`
+	Retries             int64                      `json:"retries"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Upper bounds of the latency buckets, and their names in apiLatency.
+var (
+	latencyBuckets     = []time.Duration{time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond, time.Second}
+	latencyBucketNames = []string{"<=1ms", "<=10ms", "<=100ms", "<=1s", ">1s"}
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method GetStats returns and resets the workload counters of the repository.
+
+Besides the counters of the native workload document, apiCalls counts the calls
+to each method and apiLatency buckets their latency. The duration is in milliseconds
+since the counters were last reset.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - A JSON document.
+*/
+func (repo *Repository) GetStats(ctx context.Context) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	now := repo.now()
+	result := statsDocument{
+		Workload: workloadDocument{
+			AddedRecords:   repo.stats.addedRecords,
+			APICalls:       map[string]int64{},
+			APILatency:     map[string]latencyDocument{},
+			DeletedRecords: repo.stats.deletedRecords,
+			Duration:       now.Sub(repo.stats.resetTime).Milliseconds(),
+			Reevaluations:  repo.stats.reevaluations,
+			RedoTriggers:   repo.stats.redoTriggers,
+		},
+	}
+
+	for method, anAPIStatistics := range repo.stats.apiCalls {
+		result.Workload.APICalls[method] = anAPIStatistics.count
+		latency := latencyDocument{}
+
+		for index, count := range anAPIStatistics.latency {
+			latency[latencyBucketNames[index]] = count
+		}
+
+		result.Workload.APILatency[method] = latency
+	}
+
+	repo.stats = statistics{resetTime: now}
+
+	return marshal(result)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+/*
+Method observe counts a call to a method and its latency.
+It is meant to be deferred, with the start time, once the mutex is held.
+
+Input
+  - method: The name of the method, as in the native API, e.g. "addRecord".
+  - start: When the call started.
+*/
+func (repo *Repository) observe(method string, start time.Time) {
+	if repo.stats.apiCalls == nil {
+		repo.stats.apiCalls = map[string]*apiStatistics{}
+	}
+
+	anAPIStatistics, found := repo.stats.apiCalls[method]
+	if !found {
+		anAPIStatistics = &apiStatistics{latency: make([]int64, len(latencyBucketNames))}
+		repo.stats.apiCalls[method] = anAPIStatistics
+	}
+
+	elapsed := repo.now().Sub(start)
+	bucket := len(latencyBuckets)
+
+	for index, upperBound := range latencyBuckets {
+		if elapsed <= upperBound {
+			bucket = index
+
+			break
+		}
+	}
+
+	anAPIStatistics.count++
+	anAPIStatistics.latency[bucket]++
+}
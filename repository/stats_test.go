@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statsResponse struct {
+	Workload struct {
+		AddedRecords   int64                       `json:"addedRecords"`
+		APICalls       map[string]int64            `json:"apiCalls"`
+		APILatency     map[string]map[string]int64 `json:"apiLatency"`
+		DeletedRecords int64                       `json:"deletedRecords"`
+		Duration       *int64                      `json:"duration"`
+		Reevaluations  int64                       `json:"reevaluations"`
+		RedoTriggers   int64                       `json:"redoTriggers"`
+	} `json:"workload"`
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_GetStats(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	record1 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R1",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`,
+	}
+	record2 := record.Record{
+		DataSource: "CUSTOMERS",
+		ID:         "R2",
+		JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789"}`,
+	}
+	addRecords(ctx, test, repo, record1, record2)
+
+	// Deleting a record from an entity that remains queues a redo record.
+	_, err := repo.DeleteRecord(ctx, record2.DataSource, record2.ID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	_, err = repo.ReevaluateRecord(ctx, record1.DataSource, record1.ID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	response := getStats(test, repo)
+	assert.Equal(test, int64(2), response.Workload.AddedRecords)
+	assert.Equal(test, int64(1), response.Workload.DeletedRecords)
+	assert.Equal(test, int64(1), response.Workload.Reevaluations)
+	assert.Equal(test, int64(1), response.Workload.RedoTriggers)
+	assert.Equal(
+		test,
+		map[string]int64{"addRecord": 2, "deleteRecord": 1, "reevaluateRecord": 1},
+		response.Workload.APICalls,
+	)
+	assert.NotNil(test, response.Workload.Duration)
+
+	latency := int64(0)
+	for _, count := range response.Workload.APILatency["addRecord"] {
+		latency += count
+	}
+
+	assert.Equal(test, int64(2), latency)
+
+	response = getStats(test, repo)
+	assert.Zero(test, response.Workload.AddedRecords)
+	assert.Zero(test, response.Workload.RedoTriggers)
+	assert.Empty(test, response.Workload.APICalls)
+}
+
+func TestRepository_GetStats_clock(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aClock := clock.NewFake(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	repo := &repository.Repository{Clock: aClock}
+	addRecords(ctx, test, repo, record.Record{DataSource: "CUSTOMERS", ID: "R1", JSON: `{"NAME_FULL": "ROBERT SMITH"}`})
+
+	aClock.Advance(1500 * time.Millisecond)
+
+	response := getStats(test, repo)
+	require.NotNil(test, response.Workload.Duration)
+	assert.Equal(test, int64(1500), *response.Workload.Duration)
+	assert.Equal(
+		test,
+		map[string]int64{"<=1ms": 1, "<=10ms": 0, "<=100ms": 0, "<=1s": 0, ">1s": 0},
+		response.Workload.APILatency["addRecord"],
+	)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getStats(test *testing.T, repo *repository.Repository) *statsResponse {
+	test.Helper()
+
+	actual, err := repo.GetStats(test.Context())
+	require.NoError(test, err)
+
+	response := &statsResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+
+	return response
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"slices"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+/*
+Function DefaultDataSources returns the data source codes registered in the default Senzing configuration.
+
+Output
+  - A new list of data source codes.
+*/
+func DefaultDataSources() []string {
+	return []string{"TEST", "SEARCH"}
+}
+
+/*
+Function ValidateRecord checks a record definition the way the native Senzing binary does before loading it.
+
+The record definition must be a JSON object.
+DATA_SOURCE and RECORD_ID keys in the record definition must match dataSourceCode and recordID.
+The data source code must be one of dataSources.
+
+Input
+  - dataSources: The data source codes registered in the active configuration.
+    If empty, those of the default configuration, DefaultDataSources(), are used.
+  - dataSourceCode: Identifies the provenance of the data. If empty, the DATA_SOURCE key is used.
+  - recordID: The unique identifier within the records of the same data source. If empty, it is not checked.
+  - recordDefinition: A JSON document containing the record.
+
+Output
+  - A szerror bad-input error describing the first problem found; otherwise nil.
+*/
+func ValidateRecord(dataSources []string, dataSourceCode string, recordID string, recordDefinition string) error {
+	jsonData, err := parseRecordDefinition(recordDefinition)
+	if err != nil {
+		return err
+	}
+
+	if value, found := jsonData["DATA_SOURCE"]; found {
+		if len(dataSourceCode) > 0 && stringify(value) != dataSourceCode {
+			return newError(23, "Conflicting DATA_SOURCE values '%s' and '%s'", dataSourceCode, stringify(value))
+		}
+
+		dataSourceCode = stringify(value)
+	}
+
+	if value, found := jsonData["RECORD_ID"]; found && len(recordID) > 0 && stringify(value) != recordID {
+		return newError(24, "Conflicting RECORD_ID values '%s' and '%s'", recordID, stringify(value))
+	}
+
+	if len(dataSources) == 0 {
+		dataSources = DefaultDataSources()
+	}
+
+	if len(dataSourceCode) > 0 && !slices.Contains(dataSources, dataSourceCode) {
+		return newError(2207, "Data source code [%s] does not exist.", dataSourceCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestValidateRecord(test *testing.T) {
+	test.Parallel()
+
+	dataSources := []string{"CUSTOMERS"}
+	testCases := []struct {
+		name             string
+		dataSources      []string
+		dataSourceCode   string
+		recordID         string
+		recordDefinition string
+		expectedErr      error
+	}{
+		{name: "valid", dataSourceCode: "CUSTOMERS", recordID: "1001", recordDefinition: `{"RECORD_ID": "1001"}`},
+		{
+			name:             "numeric RECORD_ID",
+			dataSourceCode:   "CUSTOMERS",
+			recordID:         "1001",
+			recordDefinition: `{"RECORD_ID": 1001}`,
+		},
+		{name: "default registry", dataSources: []string{}, dataSourceCode: "TEST", recordDefinition: `{}`},
+		{
+			name:             "unknown data source in default registry",
+			dataSources:      []string{},
+			dataSourceCode:   "CUSTOMERS",
+			recordDefinition: `{}`,
+			expectedErr:      szerror.ErrSzUnknownDataSource,
+		},
+		{name: "preview", recordDefinition: `{"DATA_SOURCE": "CUSTOMERS"}`},
+		{name: "malformed", recordDefinition: "}{", expectedErr: szerror.ErrSzBadInput},
+		{name: "not an object", recordDefinition: `["CUSTOMERS"]`, expectedErr: szerror.ErrSzBadInput},
+		{
+			name:             "conflicting DATA_SOURCE",
+			dataSourceCode:   "CUSTOMERS",
+			recordDefinition: `{"DATA_SOURCE": "OTHER"}`,
+			expectedErr:      szerror.ErrSzBadInput,
+		},
+		{
+			name:             "conflicting RECORD_ID",
+			recordID:         "1001",
+			recordDefinition: `{"RECORD_ID": "1002"}`,
+			expectedErr:      szerror.ErrSzBadInput,
+		},
+		{
+			name:             "unknown data source",
+			dataSourceCode:   "OTHER",
+			recordDefinition: `{}`,
+			expectedErr:      szerror.ErrSzUnknownDataSource,
+		},
+		{
+			name:             "unknown data source in preview",
+			recordDefinition: `{"DATA_SOURCE": "OTHER"}`,
+			expectedErr:      szerror.ErrSzUnknownDataSource,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			registry := dataSources
+			if testCase.dataSources != nil {
+				registry = testCase.dataSources
+			}
+
+			err := repository.ValidateRecord(
+				registry,
+				testCase.dataSourceCode,
+				testCase.recordID,
+				testCase.recordDefinition,
+			)
+			if testCase.expectedErr == nil {
+				require.NoError(test, err)
+			} else {
+				require.ErrorIs(test, err, testCase.expectedErr)
+			}
+		})
+	}
+}
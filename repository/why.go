@@ -0,0 +1,358 @@
+package repository
+
+import (
+	"context"
+	"slices"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type focusRecordDocument struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+}
+
+type whyDocument struct {
+	WhyResults []whyResultDocument `json:"WHY_RESULTS"`
+	Entities   []entityDocument    `json:"ENTITIES"`
+}
+
+type whyMatchInfoDocument struct {
+	WhyKey         string `json:"WHY_KEY"`
+	WhyErruleCode  string `json:"WHY_ERRULE_CODE"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+}
+
+type whyResultDocument struct {
+	InternalID    int64                 `json:"INTERNAL_ID,omitempty"`
+	EntityID      int64                 `json:"ENTITY_ID"`
+	FocusRecords  []focusRecordDocument `json:"FOCUS_RECORDS,omitempty"`
+	InternalID2   int64                 `json:"INTERNAL_ID_2,omitempty"`
+	EntityID2     int64                 `json:"ENTITY_ID_2,omitempty"`
+	FocusRecords2 []focusRecordDocument `json:"FOCUS_RECORDS_2,omitempty"`
+	MatchInfo     whyMatchInfoDocument  `json:"MATCH_INFO"`
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+Method WhyEntities explains the decision the resolver recorded between two entities.
+
+Entities that are one are explained by the strongest of their resolution steps, as HowEntityByEntityID lists them.
+Other entities are explained by their relationship, as RELATED_ENTITIES lists it.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID1: The entity ID for the starting entity of the search path.
+  - entityID2: The entity ID for the ending entity of the search path.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document. If no rule relates the entities, the MATCH_INFO values are empty.
+*/
+func (repo *Repository) WhyEntities(
+	ctx context.Context,
+	entityID1 int64,
+	entityID2 int64,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("whyEntities", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	entities, err := repo.getEntities([]int64{entityID1, entityID2})
+	if err != nil {
+		return "", err
+	}
+
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			EntityID:  entityID1,
+			EntityID2: entityID2,
+			MatchInfo: repo.explainEntities(entities[0], entities[1]).whyDocument(),
+		}},
+	}
+	result.Entities = repo.entityDocuments(entities, flags)
+
+	return marshal(result)
+}
+
+/*
+Method WhyRecordInEntity explains why a record belongs to its entity.
+
+The explanation is the first resolution step of the entity involving the record, as HowEntityByEntityID lists it.
+That is the step the record joined by or, for the first record of an entity, the step another record joined it by.
+A record alone in its entity has no explanation.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document.
+*/
+func (repo *Repository) WhyRecordInEntity(
+	ctx context.Context,
+	dataSourceCode string,
+	recordID string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("whyRecordInEntity", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	aRecord, err := repo.getRecord(dataSourceCode, recordID)
+	if err != nil {
+		return "", err
+	}
+
+	anEntity := repo.entities[aRecord.entityID]
+
+	var decision matchInfo
+
+	for _, aStep := range repo.resolutionSteps(anEntity) {
+		if slices.Contains(aStep.inbound, aRecord.key()) || slices.Contains(aStep.records, aRecord.key()) {
+			decision = aStep.match
+
+			break
+		}
+	}
+
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			InternalID:   aRecord.internalID,
+			EntityID:     anEntity.id,
+			FocusRecords: []focusRecordDocument{aRecord.focusDocument()},
+			MatchInfo:    decision.whyDocument(),
+		}},
+	}
+	result.Entities = repo.entityDocuments([]*entity{anEntity}, flags)
+
+	return marshal(result)
+}
+
+/*
+Method WhyRecords explains the decision the resolver recorded between two records.
+
+Records of the same entity are explained by the resolution step that joined them, as HowEntityByEntityID lists it.
+Records of different entities are explained by the relationship of the entities, as RELATED_ENTITIES lists it.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode1: Identifies the provenance of the data.
+  - recordID1: The unique identifier within the records of the same data source.
+  - dataSourceCode2: Identifies the provenance of the data.
+  - recordID2: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document. If no rule relates the records, the MATCH_INFO values are empty.
+*/
+func (repo *Repository) WhyRecords(
+	ctx context.Context,
+	dataSourceCode1 string,
+	recordID1 string,
+	dataSourceCode2 string,
+	recordID2 string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("whyRecords", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	record1, err := repo.getRecord(dataSourceCode1, recordID1)
+	if err != nil {
+		return "", err
+	}
+
+	record2, err := repo.getRecord(dataSourceCode2, recordID2)
+	if err != nil {
+		return "", err
+	}
+
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			InternalID:    record1.internalID,
+			EntityID:      record1.entityID,
+			FocusRecords:  []focusRecordDocument{record1.focusDocument()},
+			InternalID2:   record2.internalID,
+			EntityID2:     record2.entityID,
+			FocusRecords2: []focusRecordDocument{record2.focusDocument()},
+			MatchInfo:     repo.explainRecords(record1, record2).whyDocument(),
+		}},
+	}
+	result.Entities = repo.entityDocuments(
+		[]*entity{repo.entities[record1.entityID], repo.entities[record2.entityID]},
+		flags,
+	)
+
+	return marshal(result)
+}
+
+/*
+Method WhySearch explains how an entity scores against a set of search attributes.
+
+The entity is scored as SearchByAttributes scores it, so an entity SearchByAttributes
+does not return has empty MATCH_INFO values.
+
+Input
+  - ctx: A context to control lifecycle.
+  - attributes: A JSON document containing the attributes desired in the result set.
+  - entityID: The identifier of the entity to explain.
+  - searchProfile: The name of the search profile. An empty string uses the "SEARCH" profile.
+  - flags: Flags used to control information returned.
+
+Output
+  - A JSON document.
+*/
+func (repo *Repository) WhySearch(
+	ctx context.Context,
+	attributes string,
+	entityID int64,
+	searchProfile string,
+	flags int64,
+) (string, error) {
+	_ = ctx
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.initialize()
+	defer repo.observe("whySearch", repo.now())
+
+	if err := repo.checkLicense(); err != nil {
+		return "", err
+	}
+
+	results, err := repo.search(attributes, searchProfile)
+	if err != nil {
+		return "", err
+	}
+
+	anEntity, err := repo.getEntity(entityID)
+	if err != nil {
+		return "", err
+	}
+
+	var decision matchInfo
+
+	for _, aSearchResult := range results {
+		if aSearchResult.entity == anEntity {
+			decision = aSearchResult.match
+		}
+	}
+
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			EntityID:  entityID,
+			MatchInfo: decision.whyDocument(),
+		}},
+	}
+	result.Entities = repo.entityDocuments([]*entity{anEntity}, flags)
+
+	return marshal(result)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the documents of distinct entities, in order.
+func (repo *Repository) entityDocuments(entities []*entity, flags int64) []entityDocument {
+	result := []entityDocument{}
+	seen := []int64{}
+
+	for _, anEntity := range entities {
+		if slices.Contains(seen, anEntity.id) {
+			continue
+		}
+
+		seen = append(seen, anEntity.id)
+		result = append(result, repo.entityDocument(anEntity, flags))
+	}
+
+	return result
+}
+
+// Return the decision the resolver recorded between two entities, or between the resolution steps of one.
+func (repo *Repository) explainEntities(entity1 *entity, entity2 *entity) matchInfo {
+	var result matchInfo
+
+	if entity1 == entity2 {
+		for index, aStep := range repo.resolutionSteps(entity1) {
+			if index == 0 || aStep.match.stronger(result) {
+				result = aStep.match
+			}
+		}
+
+		return result
+	}
+
+	for _, aRelationship := range repo.relationships(entity1) {
+		if aRelationship.entityID == entity2.id {
+			result = aRelationship.match
+		}
+	}
+
+	return result
+}
+
+// Return the decision the resolver recorded between two records.
+func (repo *Repository) explainRecords(record1 *record, record2 *record) matchInfo {
+	if record1.entityID != record2.entityID {
+		return repo.explainEntities(repo.entities[record1.entityID], repo.entities[record2.entityID])
+	}
+
+	key1 := record1.key()
+	key2 := record2.key()
+
+	for _, aStep := range repo.resolutionSteps(repo.entities[record1.entityID]) {
+		if (slices.Contains(aStep.inbound, key1) && slices.Contains(aStep.records, key2)) ||
+			(slices.Contains(aStep.inbound, key2) && slices.Contains(aStep.records, key1)) {
+			return aStep.match
+		}
+	}
+
+	return matchInfo{}
+}
+
+func (aRecord *record) focusDocument() focusRecordDocument {
+	return focusRecordDocument{
+		DataSource: aRecord.dataSource,
+		RecordID:   aRecord.recordID,
+	}
+}
+
+func (aMatchInfo matchInfo) whyDocument() whyMatchInfoDocument {
+	return whyMatchInfoDocument{
+		WhyKey:         aMatchInfo.matchKey,
+		WhyErruleCode:  aMatchInfo.errRuleCode,
+		MatchLevelCode: aMatchInfo.matchLevel,
+	}
+}
@@ -0,0 +1,256 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type whyResponse struct {
+	WhyResults []struct {
+		EntityID  int64 `json:"ENTITY_ID"`
+		EntityID2 int64 `json:"ENTITY_ID_2"`
+		MatchInfo struct {
+			WhyKey         string `json:"WHY_KEY"`
+			WhyErruleCode  string `json:"WHY_ERRULE_CODE"`
+			MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+		} `json:"MATCH_INFO"`
+	} `json:"WHY_RESULTS"`
+	Entities []struct {
+		ResolvedEntity struct {
+			EntityID int64 `json:"ENTITY_ID"`
+		} `json:"RESOLVED_ENTITY"`
+	} `json:"ENTITIES"`
+}
+
+var whyRecords = map[string]record.Record{
+	"W1": {DataSource: "CUSTOMERS", ID: "W1", JSON: `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123456789"}`},
+	"W2": {DataSource: "CUSTOMERS", ID: "W2", JSON: `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "123-45-6789"}`},
+	"W3": {DataSource: "CUSTOMERS", ID: "W3", JSON: `{"NAME_FULL": "JANE DOE", "PHONE_NUMBER": "702-555-1212"}`},
+	"W4": {DataSource: "CUSTOMERS", ID: "W4", JSON: `{"NAME_FULL": "MARY JONES", "PHONE_NUMBER": "(702) 555-1212"}`},
+	"W5": {DataSource: "CUSTOMERS", ID: "W5", JSON: `{"NAME_FULL": "PAT QUINN"}`},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRepository_WhyEntities(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getWhyRepository(ctx, test)
+
+	testCases := []struct {
+		name     string
+		entity1  string
+		entity2  string
+		expected string // MATCH_LEVEL_CODE, WHY_ERRULE_CODE and WHY_KEY.
+	}{
+		{name: "same entity", entity1: "W1", entity2: "W2", expected: "RESOLVED SF1_CNAME +NAME+SSN"},
+		{name: "related", entity1: "W3", entity2: "W4", expected: "POSSIBLY_RELATED SFF +PHONE"},
+		{name: "unrelated", entity1: "W1", entity2: "W5", expected: "  "},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := repo.WhyEntities(ctx, ids[testCase.entity1], ids[testCase.entity2], senzing.SzNoFlags)
+			require.NoError(test, err)
+
+			response := whyResults(test, actual)
+			assert.Equal(test, testCase.expected, whyMatch(response))
+			assert.Equal(test, ids[testCase.entity1], response.WhyResults[0].EntityID)
+			assert.Equal(test, ids[testCase.entity2], response.WhyResults[0].EntityID2)
+		})
+	}
+
+	_, err := repo.WhyEntities(ctx, ids["W1"], 1, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_WhyEntities_disclosed(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getWhyRepository(ctx, test)
+	repo.DiscloseRelationship("CUSTOMERS", "W1", "CUSTOMERS", "W5")
+
+	actual, err := repo.WhyEntities(ctx, ids["W1"], ids["W5"], senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	response := whyResults(test, actual)
+	assert.Equal(test, repository.MatchLevelDisclosed, response.WhyResults[0].MatchInfo.MatchLevelCode)
+	assert.Len(test, response.Entities, 2)
+
+	actual, err = repo.WhyRecords(ctx, "CUSTOMERS", "W5", "CUSTOMERS", "W1", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, repository.MatchLevelDisclosed, whyResults(test, actual).WhyResults[0].MatchInfo.MatchLevelCode)
+}
+
+func TestRepository_WhyRecordInEntity(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getWhyRepository(ctx, test)
+
+	for _, name := range []string{"W1", "W2"} {
+		actual, err := repo.WhyRecordInEntity(ctx, "CUSTOMERS", name, senzing.SzNoFlags)
+		require.NoError(test, err)
+
+		response := whyResults(test, actual)
+		assert.Equal(test, "RESOLVED SF1_CNAME +NAME+SSN", whyMatch(response))
+		assert.Equal(test, ids[name], response.WhyResults[0].EntityID)
+		assert.Len(test, response.Entities, 1)
+	}
+
+	actual, err := repo.WhyRecordInEntity(ctx, "CUSTOMERS", "W5", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, "  ", whyMatch(whyResults(test, actual)))
+
+	_, err = repo.WhyRecordInEntity(ctx, "CUSTOMERS", "Z", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_WhyRecords(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo, ids := getWhyRepository(ctx, test)
+
+	actual, err := repo.WhyRecords(ctx, "CUSTOMERS", "W3", "CUSTOMERS", "W4", senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	response := whyResults(test, actual)
+	assert.Equal(test, "POSSIBLY_RELATED SFF +PHONE", whyMatch(response))
+	assert.Equal(test, ids["W3"], response.WhyResults[0].EntityID)
+	assert.Equal(test, ids["W4"], response.WhyResults[0].EntityID2)
+	assert.Len(test, response.Entities, 2)
+
+	_, err = repo.WhyRecords(ctx, "CUSTOMERS", "W3", "CUSTOMERS", "Z", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestRepository_WhyRecords_recordedDecision(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := &repository.Repository{}
+	addRecords(
+		ctx,
+		test,
+		repo,
+		record.Record{
+			DataSource: "CUSTOMERS",
+			ID:         "M1",
+			JSON:       `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "111-22-3333"}`,
+		},
+		record.Record{
+			DataSource: "CUSTOMERS",
+			ID:         "M2",
+			JSON:       `{"NAME_FULL": "ROBERT SMITH", "DATE_OF_BIRTH": "1980-01-01", "PHONE_NUMBER": "702-555-1212"}`,
+		},
+		record.Record{
+			DataSource: "CUSTOMERS",
+			ID:         "M3",
+			JSON: `{"NAME_FULL": "ROBERT SMITH", "SSN_NUMBER": "111223333", ` +
+				`"DATE_OF_BIRTH": "1980-01-01", "PHONE_NUMBER": "702-555-1212"}`,
+		},
+	)
+
+	// M3 joins M1, then the entity of M2 merges in. Later rules do not change the recorded decisions.
+	repo.Rules = []repository.Rule{
+		{Code: "CNAME", MatchLevel: repository.MatchLevelNameOnly, Required: []string{"NAME"}},
+	}
+
+	actual, err := repo.WhyRecords(ctx, "CUSTOMERS", "M1", "CUSTOMERS", "M2", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, "RESOLVED CNAME_CFF_CEXCL +NAME+DOB+PHONE", whyMatch(whyResults(test, actual)))
+
+	actual, err = repo.WhyRecords(ctx, "CUSTOMERS", "M3", "CUSTOMERS", "M1", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, "RESOLVED SF1_CNAME +NAME+SSN", whyMatch(whyResults(test, actual)))
+
+	actual, err = repo.WhyRecordInEntity(ctx, "CUSTOMERS", "M2", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, "RESOLVED CNAME_CFF_CEXCL +NAME+DOB+PHONE", whyMatch(whyResults(test, actual)))
+
+	entityID := getEntityID(test, repo, record.Record{DataSource: "CUSTOMERS", ID: "M1"})
+	actual, err = repo.WhyEntities(ctx, entityID, entityID, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, "RESOLVED CNAME_CFF_CEXCL +NAME+DOB+PHONE", whyMatch(whyResults(test, actual)))
+}
+
+func TestRepository_WhySearch(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	repo := getSearchRepository(ctx, test)
+	searchResult, err := repo.SearchByAttributes(ctx, searchAttributes, "", senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	expected := searchMatches(test, searchResult)
+	for index, aRecord := range searchRecords[:len(expected)] {
+		actual, err := repo.WhySearch(ctx, searchAttributes, getEntityID(test, repo, aRecord), "", senzing.SzNoFlags)
+		require.NoError(test, err)
+
+		matchInfo := whyResults(test, actual).WhyResults[0].MatchInfo
+		assert.Equal(test, expected[index], matchInfo.MatchLevelCode+matchInfo.WhyKey)
+	}
+
+	actual, err := repo.WhySearch(
+		ctx,
+		searchAttributes,
+		getEntityID(test, repo, searchRecords[3]),
+		"",
+		senzing.SzNoFlags,
+	)
+	require.NoError(test, err)
+	assert.Equal(test, "  ", whyMatch(whyResults(test, actual)))
+
+	_, err = repo.WhySearch(ctx, searchAttributes, 1, "", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+
+	_, err = repo.WhySearch(ctx, searchAttributes, getEntityID(test, repo, searchRecords[0]), "}{", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Add whyRecords and return the entity ID of each record.
+func getWhyRepository(ctx context.Context, test *testing.T) (*repository.Repository, map[string]int64) {
+	test.Helper()
+
+	repo := &repository.Repository{}
+	ids := map[string]int64{}
+
+	for _, name := range []string{"W1", "W2", "W3", "W4", "W5"} {
+		addRecords(ctx, test, repo, whyRecords[name])
+	}
+
+	for name, aRecord := range whyRecords {
+		ids[name] = getEntityID(test, repo, aRecord)
+	}
+
+	return repo, ids
+}
+
+func whyMatch(response *whyResponse) string {
+	matchInfo := response.WhyResults[0].MatchInfo
+
+	return matchInfo.MatchLevelCode + " " + matchInfo.WhyErruleCode + " " + matchInfo.WhyKey
+}
+
+func whyResults(test *testing.T, actual string) *whyResponse {
+	test.Helper()
+
+	response := &whyResponse{}
+	require.NoError(test, json.Unmarshal([]byte(actual), response))
+	require.Len(test, response.WhyResults, 1)
+
+	return response
+}
@@ -2,8 +2,14 @@ package szabstractfactory
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
 	"github.com/senzing-garage/sz-sdk-go-mock/szconfigmanager"
 	"github.com/senzing-garage/sz-sdk-go-mock/szdiagnostic"
 	"github.com/senzing-garage/sz-sdk-go-mock/szengine"
@@ -14,12 +20,45 @@ import (
 /*
 Szabstractfactory is an implementation of the [senzing.SzAbstractFactory] interface.
 
+If Repository is set, the SzEngine objects created share its state
+instead of returning the canned XxxResult values,
+and the SzDiagnostic objects created purge it and look up its feature library.
+
+The objects created share one [faults.Injector].
+If Chaos is set, e.g. to [faults.Soak], every call of the objects created may suffer its errors and latency,
+drawn from Random.
+During an outage begun by StartOutage, every call of the objects created fails,
+until EndOutage or Reinitialize is called.
+
+If Clock is set, the objects created tell time by it, e.g. in trace durations, observer messages
+and the SYS_CREATE_DT of registered configurations.
+So does Repository, e.g. in license expiry and statistics, unless it has a Clock of its own.
+
+If ProductProfile is set, the SzProduct objects created return the documents of that built-in profile,
+e.g. [szproduct.ProfileV4], instead of GetLicenseResult and GetVersionResult.
+If Repository is also set, the SzEngine objects created enforce the license of that profile.
+
+If Random is set, the randomized behavior of the objects created draws from it,
+so that a run can be reproduced from its seed.
+If it is not set when needed, it is made by [random.NewFromEnvironment], honoring SZ_MOCK_SEED.
+If Random or SZ_MOCK_SEED is set, the entity IDs of Repository have random gaps drawn from Random,
+unless Repository has a Random of its own; otherwise they are sequential, from 100001.
+ReportSeed tells the seed of a failing test.
+
+If Settings is set, the SzDiagnostic objects created describe the data stores it configures
+instead of returning GetRepositoryInfoResult.
+
+If ValidateRecords is set, the SzEngine objects created reject invalid record definitions,
+including data source codes missing from GetDataSourceRegistryResult.
+
 [senzing.SzAbstractFactory]: https://pkg.go.dev/github.com/senzing-garage/sz-sdk-go/senzing#SzAbstractFactory
 */
 type Szabstractfactory struct {
 	AddConfigResult                         int64
 	AddRecordResult                         string
+	Chaos                                   faults.Chaos
 	CheckRepositoryPerformanceResult        string
+	Clock                                   clock.Clock
 	CountRedoRecordsResult                  int64
 	CreateConfigResult                      uintptr
 	DeleteRecordResult                      string
@@ -52,14 +91,23 @@ type Szabstractfactory struct {
 	HowEntityByEntityIDResult               string
 	ImportConfigResult                      uintptr
 	ProcessRedoRecordResult                 string
+	ProductProfile                          string
+	Random                                  *random.Source
 	ReevaluateEntityResult                  string
 	ReevaluateRecordResult                  string
 	RegisterDataSourceResult                string
+	Repository                              *repository.Repository
 	SearchByAttributesResult                string
+	Settings                                string
 	UnregisterDataSourceResult              string
+	ValidateRecords                         bool
 	WhyEntitiesResult                       string
 	WhyRecordInEntityResult                 string
 	WhyRecordsResult                        string
+	WhySearchResult                         string
+	faults                                  *faults.Injector
+	mutex                                   sync.Mutex
+	randomMade                              bool // If true, Random was made by the factory, not set by the caller.
 }
 
 // ----------------------------------------------------------------------------
@@ -92,10 +140,16 @@ Output
   - An SzConfigManager object.
 */
 func (factory *Szabstractfactory) CreateConfigManager(ctx context.Context) (senzing.SzConfigManager, error) {
-	var err error
-
 	_ = ctx
+
+	injector, err := factory.getFaults()
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
 	result := &szconfigmanager.Szconfigmanager{
+		Clock:                    factory.Clock,
+		Faults:                   injector,
 		RegisterConfigResult:     factory.AddConfigResult,
 		GetConfigResult:          factory.GetConfigResult,
 		GetConfigRegistryResult:  factory.GetConfigRegistryResult,
@@ -116,13 +170,26 @@ Output
   - An SzDiagnostic object.
 */
 func (factory *Szabstractfactory) CreateDiagnostic(ctx context.Context) (senzing.SzDiagnostic, error) {
-	var err error
-
 	_ = ctx
+
+	injector, err := factory.getFaults()
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	aRepository, err := factory.getRepository()
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
 	result := &szdiagnostic.Szdiagnostic{
+		Clock:                            factory.Clock,
+		Faults:                           injector,
 		CheckRepositoryPerformanceResult: factory.CheckRepositoryPerformanceResult,
 		GetRepositoryInfoResult:          factory.GetRepositoryInfoResult,
 		GetFeatureResult:                 factory.GetFeatureResult,
+		Repository:                       aRepository,
+		Settings:                         factory.Settings,
 	}
 
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
@@ -139,12 +206,24 @@ Output
   - An SzEngine object.
 */
 func (factory *Szabstractfactory) CreateEngine(ctx context.Context) (senzing.SzEngine, error) {
-	var err error
-
 	_ = ctx
+
+	injector, err := factory.getFaults()
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	aRepository, err := factory.getRepository()
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
 	result := &szengine.Szengine{
+		Clock:                                   factory.Clock,
+		Faults:                                  injector,
 		AddRecordResult:                         factory.AddRecordResult,
 		CountRedoRecordsResult:                  factory.CountRedoRecordsResult,
+		DataSources:                             dataSourceCodes(factory.GetDataSourceRegistryResult),
 		DeleteRecordResult:                      factory.DeleteRecordResult,
 		ExportConfigResult:                      factory.ExportConfigResult,
 		ExportCsvEntityReportResult:             factory.ExportCsvEntityReportResult,
@@ -168,10 +247,22 @@ func (factory *Szabstractfactory) CreateEngine(ctx context.Context) (senzing.SzE
 		ProcessRedoRecordResult:                 factory.ProcessRedoRecordResult,
 		ReevaluateEntityResult:                  factory.ReevaluateEntityResult,
 		ReevaluateRecordResult:                  factory.ReevaluateRecordResult,
+		Repository:                              aRepository,
 		SearchByAttributesResult:                factory.SearchByAttributesResult,
+		ValidateRecords:                         factory.ValidateRecords,
 		WhyEntitiesResult:                       factory.WhyEntitiesResult,
 		WhyRecordInEntityResult:                 factory.WhyRecordInEntityResult,
 		WhyRecordsResult:                        factory.WhyRecordsResult,
+		WhySearchResult:                         factory.WhySearchResult,
+	}
+
+	if result.Repository != nil && len(factory.ProductProfile) > 0 {
+		profile, profileErr := szproduct.GetProfile(factory.ProductProfile)
+		if profileErr != nil {
+			return nil, wraperror.Errorf(profileErr, wraperror.NoMessage)
+		}
+
+		err = result.Repository.SetLicense(profile.License)
 	}
 
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
@@ -188,14 +279,30 @@ Output
   - An SzProduct object.
 */
 func (factory *Szabstractfactory) CreateProduct(ctx context.Context) (senzing.SzProduct, error) {
-	var err error
-
 	_ = ctx
+
+	injector, err := factory.getFaults()
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
 	result := &szproduct.Szproduct{
+		Clock:            factory.Clock,
+		Faults:           injector,
 		GetLicenseResult: factory.GetLicenseResult,
 		GetVersionResult: factory.GetVersionResult,
 	}
 
+	if len(factory.ProductProfile) > 0 {
+		profile, profileErr := szproduct.GetProfile(factory.ProductProfile)
+		if profileErr != nil {
+			return nil, wraperror.Errorf(profileErr, wraperror.NoMessage)
+		}
+
+		result.GetLicenseResult = profile.License
+		result.GetVersionResult = profile.Version
+	}
+
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
@@ -203,15 +310,173 @@ func (factory *Szabstractfactory) CreateProduct(ctx context.Context) (senzing.Sz
 Method Reinitialize re-initializes the Senzing objects created by the AbstractFactory
 with a specific Senzing configuration JSON document identifier.
 
+Reinitializing ends the outage of the objects, if any.
+
 Input
   - ctx: A context to control lifecycle.
   - configID: The Senzing configuration JSON document identifier used for the initialization.
 */
 func (factory *Szabstractfactory) Reinitialize(ctx context.Context, configID int64) error {
-	var err error
+	_ = configID
+
+	return factory.EndOutage(ctx)
+}
 
+// ----------------------------------------------------------------------------
+// Public non-interface methods
+// ----------------------------------------------------------------------------
+
+/*
+Method EndOutage ends the outage of the Senzing objects created by the AbstractFactory, if any.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (factory *Szabstractfactory) EndOutage(ctx context.Context) error {
 	_ = ctx
-	_ = configID
 
-	return err
+	injector, err := factory.getFaults()
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	injector.EndOutage()
+
+	return nil
+}
+
+/*
+Method ReportSeed logs the seed of Random, should a test fail,
+so that setting SZ_MOCK_SEED to it reproduces the failing run.
+
+Input
+  - tb: The test, benchmark or fuzz target using the Senzing objects created by the AbstractFactory.
+*/
+func (factory *Szabstractfactory) ReportSeed(tb random.TB) error {
+	factory.mutex.Lock()
+	defer factory.mutex.Unlock()
+
+	source, err := factory.getRandom()
+	if err != nil {
+		return err
+	}
+
+	source.ReportSeed(tb)
+
+	return nil
+}
+
+/*
+Method StartOutage starts an outage of the Senzing objects created by the AbstractFactory,
+whether they are created before or after the outage is started.
+Once the outage begins, every call of the objects fails, e.g. with an SzDatabaseConnectionLostError,
+until EndOutage or Reinitialize is called.
+
+Input
+  - ctx: A context to control lifecycle.
+  - outage: The outage, e.g. faults.Outage{After: 100, Kind: faults.EngineCrash}.
+
+Output
+  - An SzBadInputError if the kind of the outage is unknown.
+*/
+func (factory *Szabstractfactory) StartOutage(ctx context.Context, outage faults.Outage) error {
+	_ = ctx
+
+	injector, err := factory.getFaults()
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	err = injector.StartOutage(outage)
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Get the repository shared by the objects created,
+// telling time by Clock and drawing from Random, if set by the caller or SZ_MOCK_SEED, unless it has its own.
+func (factory *Szabstractfactory) getRepository() (*repository.Repository, error) {
+	factory.mutex.Lock()
+	defer factory.mutex.Unlock()
+
+	if factory.Repository == nil {
+		return nil, nil
+	}
+
+	var source *random.Source
+
+	switch {
+	case factory.Random != nil && !factory.randomMade:
+		source = factory.Random
+	case random.IsSeededByEnvironment():
+		var err error
+
+		source, err = factory.getRandom()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	factory.Repository.SetDefaults(factory.Clock, source)
+
+	return factory.Repository, nil
+}
+
+// Get the injector shared by the objects created, making it if needed.
+func (factory *Szabstractfactory) getFaults() (*faults.Injector, error) {
+	factory.mutex.Lock()
+	defer factory.mutex.Unlock()
+
+	if factory.faults == nil {
+		if factory.Chaos != (faults.Chaos{}) {
+			_, err := factory.getRandom()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		factory.faults = &faults.Injector{Chaos: factory.Chaos, Clock: factory.Clock, Random: factory.Random}
+	}
+
+	return factory.faults, nil
+}
+
+// Get Random, making it from the environment if needed. The caller holds the mutex.
+func (factory *Szabstractfactory) getRandom() (*random.Source, error) {
+	if factory.Random == nil {
+		source, err := random.NewFromEnvironment()
+		if err != nil {
+			return nil, wraperror.Errorf(err, wraperror.NoMessage)
+		}
+
+		factory.Random = source
+		factory.randomMade = true
+	}
+
+	return factory.Random, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the DSRC_CODE values of a data source registry JSON document.
+func dataSourceCodes(dataSourceRegistry string) []string {
+	registry := struct {
+		DataSources []struct {
+			DataSourceCode string `json:"DSRC_CODE"`
+		} `json:"DATA_SOURCES"`
+	}{}
+
+	_ = json.Unmarshal([]byte(dataSourceRegistry), &registry)
+	result := make([]string, 0, len(registry.DataSources))
+
+	for _, dataSource := range registry.DataSources {
+		result = append(result, dataSource.DataSourceCode)
+	}
+
+	return result
 }
@@ -3,12 +3,21 @@ package szabstractfactory_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
 	"github.com/senzing-garage/sz-sdk-go-mock/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go-mock/szengine"
+	"github.com/senzing-garage/sz-sdk-go-mock/szproduct"
 	"github.com/senzing-garage/sz-sdk-go-mock/testdata"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,6 +61,33 @@ func TestSzAbstractFactory_CreateDiagnostic(test *testing.T) {
 	printActual(test, result)
 }
 
+func TestSzAbstractFactory_CreateDiagnostic_purgeRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.Repository = &repository.Repository{}
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	szDiagnostic, err := szAbstractFactory.CreateDiagnostic(ctx)
+	require.NoError(test, err)
+	err = szDiagnostic.PurgeRepository(ctx)
+	require.NoError(test, err)
+
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "1", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	szConfigManager, err := szAbstractFactory.CreateConfigManager(ctx)
+	require.NoError(test, err)
+	registry, err := szConfigManager.GetConfigRegistry(ctx)
+	require.NoError(test, err)
+	require.Equal(test, szAbstractFactory.GetConfigRegistryResult, registry)
+}
+
 func TestSzAbstractFactory_CreateEngine(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
@@ -66,6 +102,54 @@ func TestSzAbstractFactory_CreateEngine(test *testing.T) {
 	printActual(test, stats)
 }
 
+func TestSzAbstractFactory_CreateEngine_validateRecords(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.ValidateRecords = true
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "TEST", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+}
+
+func TestSzAbstractFactory_CreateEngine_whySearch(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	actual, err := szEngine.WhySearch(ctx, `{"NAME_FULL": "BOB SMITH"}`, 100001, "", senzing.SzWhySearchDefaultFlags)
+	require.NoError(test, err)
+	require.Equal(test, szAbstractFactory.WhySearchResult, actual)
+	require.NotEmpty(test, actual)
+}
+
+func TestSzAbstractFactory_CreateProduct_clock(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.Clock = clock.NewFake(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szProduct, err := szAbstractFactory.CreateProduct(ctx)
+	require.NoError(test, err)
+	require.Equal(test, szAbstractFactory.Clock, szProduct.(*szproduct.Szproduct).Clock)
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	require.Equal(test, szAbstractFactory.Clock, szEngine.(*szengine.Szengine).Clock)
+}
+
 func TestSzAbstractFactory_CreateProduct(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
@@ -80,6 +164,262 @@ func TestSzAbstractFactory_CreateProduct(test *testing.T) {
 	printActual(test, version)
 }
 
+func TestSzAbstractFactory_CreateProduct_productProfile(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.ProductProfile = szproduct.ProfileV3
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szProduct, err := szAbstractFactory.CreateProduct(ctx)
+	require.NoError(test, err)
+	version, err := szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	require.Contains(test, version, `"COMPATIBILITY_VERSION":{"CONFIG_VERSION":"10"}`)
+
+	szAbstractFactory.ProductProfile = "v2.x"
+	_, err = szAbstractFactory.CreateProduct(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSzAbstractFactory_CreateEngine_chaos(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.Chaos = faults.Chaos{ErrorRate: 1, RetryableShare: 1}
+	szAbstractFactory.Random = random.New(42)
+	szAbstractFactory.Random.ReportSeed(test)
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+
+	szProduct, err := szAbstractFactory.CreateProduct(ctx)
+	require.NoError(test, err)
+	_, err = szProduct.GetVersion(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	require.Same(test, szEngine.(*szengine.Szengine).Faults, szProduct.(*szproduct.Szproduct).Faults)
+}
+
+func TestSzAbstractFactory_CreateEngine_chaosSeed(test *testing.T) {
+	test.Setenv(random.SeedEnvVar, "not a seed")
+
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.Chaos = faults.Soak
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	_, err := szAbstractFactory.CreateEngine(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSzAbstractFactory_StartOutage(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.Repository = &repository.Repository{}
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szAbstractFactory.StartOutage(ctx, faults.Outage{After: 1, Kind: faults.EngineCrash}))
+
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "2", `{"NAME_FULL": "JANE DOE"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+
+	szDiagnostic, err := szAbstractFactory.CreateDiagnostic(ctx)
+	require.NoError(test, err)
+	_, err = szDiagnostic.GetRepositoryInfo(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+
+	require.NoError(test, szAbstractFactory.Reinitialize(ctx, 0))
+	_, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1", senzing.SzNoFlags)
+	require.NoError(test, err)
+
+	require.NoError(test, szAbstractFactory.StartOutage(ctx, faults.Outage{Kind: faults.DatabaseUnavailable}))
+	_, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+
+	require.NoError(test, szAbstractFactory.EndOutage(ctx))
+	_, err = szDiagnostic.GetRepositoryInfo(ctx)
+	require.NoError(test, err)
+}
+
+func TestSzAbstractFactory_CreateEngine_clock(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.Clock = clock.NewFake(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC))
+	szAbstractFactory.ProductProfile = szproduct.ProfileV4
+	szAbstractFactory.Repository = &repository.Repository{}
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	require.Nil(test, szAbstractFactory.Repository.Clock)
+
+	// The license of the profile expires 2099-12-31 by the clock of the factory.
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+}
+
+func TestSzAbstractFactory_CreateEngine_recordLimitReached(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.ProductProfile = szproduct.ProfileRecordLimitReached
+	szAbstractFactory.Repository = &repository.Repository{}
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+
+	// The limit of the profile is reached before the first record.
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+}
+
+func TestSzAbstractFactory_CreateEngine_seed(test *testing.T) {
+	test.Setenv(random.SeedEnvVar, "42")
+
+	ctx := test.Context()
+	entityIDs := func() []string {
+		szAbstractFactory := getSzAbstractFactory(ctx)
+		szAbstractFactory.Repository = &repository.Repository{}
+		require.NoError(test, szAbstractFactory.ReportSeed(test))
+		require.Equal(test, uint64(42), szAbstractFactory.Random.Seed())
+
+		defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+		szEngine, err := szAbstractFactory.CreateEngine(ctx)
+		require.NoError(test, err)
+		require.Nil(test, szAbstractFactory.Repository.Random)
+
+		result := []string{}
+
+		for _, name := range []string{"BOB SMITH", "JANE DOE", "JOHN ROE"} {
+			withInfo, err := szEngine.AddRecord(ctx, "CUSTOMERS", name, `{"NAME_FULL": "`+name+`"}`, senzing.SzWithInfo)
+			require.NoError(test, err)
+
+			result = append(result, withInfo)
+		}
+
+		return result
+	}
+
+	require.Equal(test, entityIDs(), entityIDs())
+}
+
+func TestSzAbstractFactory_CreateEngine_sequentialEntityIDs(test *testing.T) {
+	test.Setenv(random.SeedEnvVar, "")
+	require.NoError(test, os.Unsetenv(random.SeedEnvVar))
+
+	ctx := test.Context()
+	firstEntityID := func(szAbstractFactory *szabstractfactory.Szabstractfactory) string {
+		szAbstractFactory.Repository = &repository.Repository{}
+
+		defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+		szEngine, err := szAbstractFactory.CreateEngine(ctx)
+		require.NoError(test, err)
+
+		withInfo, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithInfo)
+		require.NoError(test, err)
+
+		return withInfo
+	}
+
+	// A Random made by the factory, rather than set, leaves entity IDs sequential.
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	require.NoError(test, szAbstractFactory.ReportSeed(test))
+	require.Contains(test, firstEntityID(szAbstractFactory), `{"ENTITY_ID":100001}`)
+
+	szAbstractFactory = getSzAbstractFactory(ctx)
+	szAbstractFactory.Random = random.New(42)
+	require.Contains(test, firstEntityID(szAbstractFactory), `{"ENTITY_ID":100007}`)
+}
+
+func TestSzAbstractFactory_ReportSeed(test *testing.T) {
+	test.Parallel()
+
+	szAbstractFactory := getSzAbstractFactory(test.Context())
+	szAbstractFactory.Random = random.New(7)
+	tb := &failingTB{}
+	require.NoError(test, szAbstractFactory.ReportSeed(tb))
+	require.Len(test, tb.cleanups, 1)
+
+	tb.cleanups[0]()
+	require.Equal(test, []string{"reproduce with SZ_MOCK_SEED=7"}, tb.logs)
+}
+
+func TestSzAbstractFactory_StartOutage_reinitializeEngine(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	szProduct, err := szAbstractFactory.CreateProduct(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szAbstractFactory.StartOutage(ctx, faults.Outage{Kind: faults.EngineCrash}))
+
+	// A supervisor sees the crash, then reinitializes the engine.
+	_, err = szEngine.GetActiveConfigID(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+	_, err = szProduct.GetVersion(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+	require.NoError(test, szEngine.(*szengine.Szengine).Reinitialize(ctx, szAbstractFactory.GetActiveConfigIDResult))
+
+	_, err = szEngine.GetActiveConfigID(ctx)
+	require.NoError(test, err)
+	_, err = szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+}
+
+func TestSzAbstractFactory_StartOutage_unknownKind(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+
+	err = szAbstractFactory.StartOutage(ctx, faults.Outage{Kind: faults.OutageKind(7)})
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = szEngine.GetActiveConfigID(ctx)
+	require.NoError(test, err)
+}
+
+func TestSzAbstractFactory_CreateEngine_productProfile(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+	szAbstractFactory.ProductProfile = szproduct.ProfileExpiredEvalLicense
+	szAbstractFactory.Repository = &repository.Repository{}
+
+	defer func() { require.NoError(test, szAbstractFactory.Close(ctx)) }()
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+}
+
 func TestSzAbstractFactory_Destroy(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
@@ -111,6 +451,24 @@ func TestSzAbstractFactory_Reinitialize(test *testing.T) {
 // Internal functions
 // ----------------------------------------------------------------------------
 
+// A failingTB is a test that has failed, keeping what it logs.
+type failingTB struct {
+	cleanups []func()
+	logs     []string
+}
+
+func (tb *failingTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *failingTB) Failed() bool {
+	return true
+}
+
+func (tb *failingTB) Logf(format string, args ...any) {
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+
 func getSzAbstractFactory(ctx context.Context) *szabstractfactory.Szabstractfactory {
 	_ = ctx
 
@@ -164,6 +522,7 @@ func getSzAbstractFactory(ctx context.Context) *szabstractfactory.Szabstractfact
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 }
 
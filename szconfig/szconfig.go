@@ -6,21 +6,23 @@ package szconfig
 import (
 	"context"
 	"strconv"
-	"time"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
-	"github.com/senzing-garage/go-observing/notifier"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
 	"github.com/senzing-garage/sz-sdk-go/szconfig"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 )
 
 type Szconfig struct {
+	Clock                       clock.Clock
 	CreateConfigResult          uintptr
 	ExportResult                string
+	Faults                      *faults.Injector
 	GetDataSourceRegistryResult string
 	ImportConfigResult          uintptr
 	isTrace                     bool
@@ -60,17 +62,29 @@ func (client *Szconfig) Export(ctx context.Context) (string, error) {
 	if client.isTrace {
 		client.traceEntry(13)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(14, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(14, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.ExportResult
+	err = client.Faults.Inject(ctx, "export")
+	if err == nil {
+		result = client.ExportResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8006, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8006,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -95,17 +109,29 @@ func (client *Szconfig) GetDataSourceRegistry(ctx context.Context) (string, erro
 	if client.isTrace {
 		client.traceEntry(15)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(16, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(16, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetDataSourceRegistryResult
+	err = client.Faults.Inject(ctx, "getDataSourceRegistry")
+	if err == nil {
+		result = client.GetDataSourceRegistryResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8008,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -134,14 +160,17 @@ func (client *Szconfig) RegisterDataSource(ctx context.Context, dataSourceCode s
 	if client.isTrace {
 		client.traceEntry(1, dataSourceCode)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(2, dataSourceCode, result, err, time.Since(entryTime))
+			client.traceExit(2, dataSourceCode, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.RegisterDataSourceResult
+	err = client.Faults.Inject(ctx, "registerDataSource")
+	if err == nil {
+		result = client.RegisterDataSourceResult
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -149,7 +178,16 @@ func (client *Szconfig) RegisterDataSource(ctx context.Context, dataSourceCode s
 				"dataSourceCode": dataSourceCode,
 				"return":         result,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8001,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -183,19 +221,31 @@ func (client *Szconfig) UnregisterDataSource(ctx context.Context, dataSourceCode
 	if client.isTrace {
 		client.traceEntry(9, dataSourceCode)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(10, dataSourceCode, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(10, dataSourceCode, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.UnregisterDataSourceResult
+	err = client.Faults.Inject(ctx, "unregisterDataSource")
+	if err == nil {
+		result = client.UnregisterDataSourceResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8004,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -234,15 +284,24 @@ func (client *Szconfig) Import(ctx context.Context, configDefinition string) err
 	if client.isTrace {
 		client.traceEntry(21, configDefinition)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(22, configDefinition, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(22, configDefinition, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8009, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8009,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -270,15 +329,24 @@ func (client *Szconfig) ImportTemplate(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(7)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(8, configDefinition, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(8, configDefinition, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8003,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -307,9 +375,11 @@ func (client *Szconfig) Initialize(
 	if client.isTrace {
 		client.traceEntry(23, instanceName, settings, verboseLogging)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(24, instanceName, settings, verboseLogging, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(24, instanceName, settings, verboseLogging, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers != nil {
@@ -319,7 +389,16 @@ func (client *Szconfig) Initialize(
 				"settings":       settings,
 				"verboseLogging": strconv.FormatInt(verboseLogging, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8007,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -339,9 +418,11 @@ func (client *Szconfig) RegisterObserver(ctx context.Context, observer observer.
 	if client.isTrace {
 		client.traceEntry(703, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(704, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers == nil {
@@ -355,7 +436,16 @@ func (client *Szconfig) RegisterObserver(ctx context.Context, observer observer.
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8702,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -375,9 +465,9 @@ func (client *Szconfig) SetLogLevel(ctx context.Context, logLevelName string) er
 	if client.isTrace {
 		client.traceEntry(705, logLevelName)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(706, logLevelName, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if !logging.IsValidLogLevelName(logLevelName) {
@@ -392,7 +482,16 @@ func (client *Szconfig) SetLogLevel(ctx context.Context, logLevelName string) er
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8703,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -424,9 +523,11 @@ func (client *Szconfig) UnregisterObserver(ctx context.Context, observer observe
 	if client.isTrace {
 		client.traceEntry(707, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(708, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers != nil {
@@ -437,7 +538,7 @@ func (client *Szconfig) UnregisterObserver(ctx context.Context, observer observe
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
+		helper.Notify(ctx, client.getClock(), client.observers, client.observerOrigin, ComponentID, 8704, err, details)
 		err = client.observers.UnregisterObserver(ctx, observer)
 
 		if !client.observers.HasObservers(ctx) {
@@ -463,15 +564,24 @@ func (client *Szconfig) VerifyConfigDefinition(ctx context.Context, configDefini
 	if client.isTrace {
 		client.traceEntry(25, configDefinition)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(26, configDefinition, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(26, configDefinition, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8010, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8010,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -482,6 +592,17 @@ func (client *Szconfig) VerifyConfigDefinition(ctx context.Context, configDefini
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// --- Time -------------------------------------------------------------------
+
+// Get the clock of the client, the real clock if none is set.
+func (client *Szconfig) getClock() clock.Clock {
+	if client.Clock == nil {
+		return clock.Real{}
+	}
+
+	return client.Clock
+}
+
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
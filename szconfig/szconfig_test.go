@@ -237,6 +237,7 @@ func getSzAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 
 	return result
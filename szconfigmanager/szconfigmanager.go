@@ -6,14 +6,17 @@ package szconfigmanager
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
 	"strconv"
-	"time"
+	"sync"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
-	"github.com/senzing-garage/go-observing/notifier"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
 	"github.com/senzing-garage/sz-sdk-go-mock/szconfig"
 	"github.com/senzing-garage/sz-sdk-go-mock/testdata"
@@ -22,15 +25,33 @@ import (
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 )
 
+/*
+Szconfigmanager is an implementation of the [senzing.SzConfigManager] interface.
+
+GetConfigRegistry lists the configurations of GetConfigRegistryResult,
+followed by those registered with RegisterConfig, which are created at the time of Clock.
+Registering a CONFIG_ID already listed replaces its entry.
+*/
 type Szconfigmanager struct {
+	Clock                    clock.Clock
+	Faults                   *faults.Injector
 	GetConfigRegistryResult  string
 	GetConfigResult          string
 	GetDefaultConfigIDResult int64
 	isTrace                  bool
 	logger                   logging.Logging
+	mutex                    sync.Mutex
 	observerOrigin           string
 	observers                subject.Subject
 	RegisterConfigResult     int64
+	registeredConfigs        []registeredConfig
+}
+
+// A registeredConfig is an entry of the configuration registry added by RegisterConfig.
+type registeredConfig struct {
+	ConfigID       int64  `json:"CONFIG_ID"`
+	ConfigComments string `json:"CONFIG_COMMENTS"`
+	SysCreateDt    string `json:"SYS_CREATE_DT"`
 }
 
 const (
@@ -38,6 +59,7 @@ const (
 	baseTen              = 10
 	initialByteArraySize = 65535
 	noError              = 0
+	sysCreateDtLayout    = "2006-01-02 15:04:05.000"
 )
 
 // ----------------------------------------------------------------------------
@@ -63,17 +85,29 @@ func (client *Szconfigmanager) CreateConfigFromConfigID(ctx context.Context, con
 	if client.isTrace {
 		client.traceEntry(7, configID)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(8, configID, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(8, configID, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = getSzConfig(ctx)
+	err = client.Faults.Inject(ctx, "createConfigFromConfigID")
+	if err == nil {
+		result = getSzConfig(ctx, client.Clock, client.Faults)
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8003,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -102,17 +136,29 @@ func (client *Szconfigmanager) CreateConfigFromString(
 	if client.isTrace {
 		client.traceEntry(23, configDefinition)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(24, configDefinition, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(24, configDefinition, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = getSzConfig(ctx)
+	err = client.Faults.Inject(ctx, "createConfigFromString")
+	if err == nil {
+		result = getSzConfig(ctx, client.Clock, client.Faults)
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8009, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8009,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -137,17 +183,29 @@ func (client *Szconfigmanager) CreateConfigFromTemplate(ctx context.Context) (se
 	if client.isTrace {
 		client.traceEntry(25)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(26, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(26, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = getSzConfig(ctx)
+	err = client.Faults.Inject(ctx, "createConfigFromTemplate")
+	if err == nil {
+		result = getSzConfig(ctx, client.Clock, client.Faults)
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8010, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8010,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -168,15 +226,26 @@ func (client *Szconfigmanager) Destroy(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(5)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(6, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(6, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	err = client.Faults.Inject(ctx, "destroy")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8002,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -206,17 +275,29 @@ func (client *Szconfigmanager) GetConfigRegistry(ctx context.Context) (string, e
 	if client.isTrace {
 		client.traceEntry(9)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(10, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(10, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetConfigRegistryResult
+	err = client.Faults.Inject(ctx, "getConfigRegistry")
+	if err == nil {
+		result = client.getConfigRegistry()
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8004,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -245,17 +326,29 @@ func (client *Szconfigmanager) GetDefaultConfigID(ctx context.Context) (int64, e
 	if client.isTrace {
 		client.traceEntry(11)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(12, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(12, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetDefaultConfigIDResult
+	err = client.Faults.Inject(ctx, "getDefaultConfigID")
+	if err == nil {
+		result = client.GetDefaultConfigIDResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8005, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8005,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -290,21 +383,34 @@ func (client *Szconfigmanager) RegisterConfig(
 	if client.isTrace {
 		client.traceEntry(1, configDefinition, configComment)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(2, configDefinition, configComment, result, err, time.Since(entryTime))
+			client.traceExit(2, configDefinition, configComment, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.RegisterConfigResult
+	err = client.Faults.Inject(ctx, "registerConfig")
+	if err == nil {
+		result = client.RegisterConfigResult
+		client.registerConfig(result, configComment)
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"configComment": configComment,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8001,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -333,17 +439,36 @@ func (client *Szconfigmanager) ReplaceDefaultConfigID(
 	if client.isTrace {
 		client.traceEntry(19, currentDefaultConfigID, newDefaultConfigID)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(20, currentDefaultConfigID, newDefaultConfigID, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(
+				20,
+				currentDefaultConfigID,
+				newDefaultConfigID,
+				err,
+				client.getClock().Now().Sub(entryTime),
+			)
+		}()
 	}
 
+	err = client.Faults.Inject(ctx, "replaceDefaultConfigID")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"newDefaultConfigID": strconv.FormatInt(newDefaultConfigID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8007,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -365,11 +490,12 @@ func (client *Szconfigmanager) SetDefaultConfig(
 	configDefinition string,
 	configComment string,
 ) (int64, error) {
-	_ = ctx
 	_ = configComment
 	_ = configDefinition
 
-	return 0, nil
+	err := client.Faults.Inject(ctx, "setDefaultConfig")
+
+	return 0, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
 /*
@@ -389,17 +515,28 @@ func (client *Szconfigmanager) SetDefaultConfigID(ctx context.Context, configID
 	if client.isTrace {
 		client.traceEntry(21, configID)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(22, configID, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(22, configID, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	err = client.Faults.Inject(ctx, "setDefaultConfigID")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"configID": strconv.FormatInt(configID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8008,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -438,9 +575,11 @@ func (client *Szconfigmanager) RegisterObserver(ctx context.Context, observer ob
 	if client.isTrace {
 		client.traceEntry(703, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(704, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers == nil {
@@ -454,7 +593,16 @@ func (client *Szconfigmanager) RegisterObserver(ctx context.Context, observer ob
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8702,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -474,9 +622,9 @@ func (client *Szconfigmanager) SetLogLevel(ctx context.Context, logLevelName str
 	if client.isTrace {
 		client.traceEntry(705, logLevelName)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(706, logLevelName, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if !logging.IsValidLogLevelName(logLevelName) {
@@ -491,7 +639,16 @@ func (client *Szconfigmanager) SetLogLevel(ctx context.Context, logLevelName str
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8703,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -523,9 +680,11 @@ func (client *Szconfigmanager) UnregisterObserver(ctx context.Context, observer
 	if client.isTrace {
 		client.traceEntry(707, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(708, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers != nil {
@@ -536,7 +695,7 @@ func (client *Szconfigmanager) UnregisterObserver(ctx context.Context, observer
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
+		helper.Notify(ctx, client.getClock(), client.observers, client.observerOrigin, ComponentID, 8704, err, details)
 
 		err = client.observers.UnregisterObserver(ctx, observer)
 
@@ -552,7 +711,7 @@ func (client *Szconfigmanager) UnregisterObserver(ctx context.Context, observer
 // Internal methods
 // ----------------------------------------------------------------------------
 
-func getSzConfig(ctx context.Context) *szconfig.Szconfig {
+func getSzConfig(ctx context.Context, aClock clock.Clock, injector *faults.Injector) *szconfig.Szconfig {
 	_ = ctx
 	testValue := &testdata.TestData{
 		Int64s:   testdata.Data1_int64s,
@@ -560,6 +719,8 @@ func getSzConfig(ctx context.Context) *szconfig.Szconfig {
 		Uintptrs: testdata.Data1_uintptrs,
 	}
 	result := &szconfig.Szconfig{
+		Clock:                       aClock,
+		Faults:                      injector,
 		RegisterDataSourceResult:    testValue.String("RegisterDataSourceResult"),
 		CreateConfigResult:          testValue.Uintptr("CreateConfigResult"),
 		GetDataSourceRegistryResult: testValue.String("GetDataSourceRegistryResult"),
@@ -570,6 +731,76 @@ func getSzConfig(ctx context.Context) *szconfig.Szconfig {
 	return result
 }
 
+// --- Registry ---------------------------------------------------------------
+
+// Get the configuration registry: GetConfigRegistryResult and the configurations registered since.
+func (client *Szconfigmanager) getConfigRegistry() string {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if len(client.registeredConfigs) == 0 {
+		return client.GetConfigRegistryResult
+	}
+
+	registry := struct {
+		Configs []json.RawMessage `json:"CONFIGS"`
+	}{}
+
+	_ = json.Unmarshal([]byte(client.GetConfigRegistryResult), &registry)
+
+	for _, aRegisteredConfig := range client.registeredConfigs {
+		entry, _ := json.Marshal(aRegisteredConfig)
+
+		index := slices.IndexFunc(registry.Configs, func(config json.RawMessage) bool {
+			aConfig := registeredConfig{}
+			_ = json.Unmarshal(config, &aConfig)
+
+			return aConfig.ConfigID == aRegisteredConfig.ConfigID
+		})
+		if index < 0 {
+			registry.Configs = append(registry.Configs, entry)
+		} else {
+			registry.Configs[index] = entry
+		}
+	}
+
+	result, _ := json.Marshal(registry)
+
+	return string(result)
+}
+
+// Add a configuration to the registry, created now.
+func (client *Szconfigmanager) registerConfig(configID int64, configComment string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	aRegisteredConfig := registeredConfig{
+		ConfigID:       configID,
+		ConfigComments: configComment,
+		SysCreateDt:    client.getClock().Now().UTC().Format(sysCreateDtLayout),
+	}
+
+	index := slices.IndexFunc(client.registeredConfigs, func(candidate registeredConfig) bool {
+		return candidate.ConfigID == configID
+	})
+	if index < 0 {
+		client.registeredConfigs = append(client.registeredConfigs, aRegisteredConfig)
+	} else {
+		client.registeredConfigs[index] = aRegisteredConfig
+	}
+}
+
+// --- Time -------------------------------------------------------------------
+
+// Get the clock of the client, the real clock if none is set.
+func (client *Szconfigmanager) getClock() clock.Clock {
+	if client.Clock == nil {
+		return clock.Real{}
+	}
+
+	return client.Clock
+}
+
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
@@ -10,6 +10,7 @@ import (
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-helpers/env"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
 	"github.com/senzing-garage/sz-sdk-go-mock/szabstractfactory"
 	"github.com/senzing-garage/sz-sdk-go-mock/szconfigmanager"
 	"github.com/senzing-garage/sz-sdk-go-mock/testdata"
@@ -89,6 +90,43 @@ func TestSzconfigmanager_GetConfigRegistry(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzconfigmanager_GetConfigRegistry_clock(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aClock := clock.NewFake(time.Date(2025, time.March, 4, 5, 6, 7, 890000000, time.UTC))
+	exampleConfig := `{"CONFIG_ID":41320074,"CONFIG_COMMENTS":"Example configuration",` +
+		`"SYS_CREATE_DT":"2023-02-16 21:43:10.171"}`
+	szConfigManager := &szconfigmanager.Szconfigmanager{
+		Clock: aClock,
+		GetConfigRegistryResult: `{"CONFIGS":[{"CONFIG_ID":1111755672,"CONFIG_COMMENTS":"canned",` +
+			`"SYS_CREATE_DT":"2023-02-16 21:43:10.154"},` + exampleConfig + `]}`,
+		RegisterConfigResult: 1111755672,
+	}
+
+	// Registering a CONFIG_ID again replaces its entry.
+	_, err := szConfigManager.RegisterConfig(ctx, "{}", "first")
+	require.NoError(test, err)
+	aClock.Advance(time.Hour)
+	_, err = szConfigManager.RegisterConfig(ctx, "{}", "second")
+	require.NoError(test, err)
+
+	szConfigManager.RegisterConfigResult = 2222222222
+	aClock.Advance(time.Hour)
+	_, err = szConfigManager.RegisterConfig(ctx, "{}", "third")
+	require.NoError(test, err)
+
+	actual, err := szConfigManager.GetConfigRegistry(ctx)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"CONFIGS":[`+
+			`{"CONFIG_ID":1111755672,"CONFIG_COMMENTS":"second","SYS_CREATE_DT":"2025-03-04 06:06:07.890"},`+
+			exampleConfig+`,`+
+			`{"CONFIG_ID":2222222222,"CONFIG_COMMENTS":"third","SYS_CREATE_DT":"2025-03-04 07:06:07.890"}]}`,
+		actual,
+	)
+}
+
 func TestSzconfigmanager_GetDefaultConfigID(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
@@ -250,6 +288,7 @@ func getSzAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 
 	return result
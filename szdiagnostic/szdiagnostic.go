@@ -7,22 +7,27 @@ package szdiagnostic
 import (
 	"context"
 	"strconv"
-	"time"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
-	"github.com/senzing-garage/go-observing/notifier"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
 	"github.com/senzing-garage/sz-sdk-go/szdiagnostic"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 )
 
 type Szdiagnostic struct {
 	CheckRepositoryPerformanceResult string
+	Clock                            clock.Clock
+	Faults                           *faults.Injector
 	GetFeatureResult                 string
 	GetRepositoryInfoResult          string
+	Repository                       *repository.Repository
+	Settings                         string
 	isTrace                          bool
 	logger                           logging.Logging
 	observerOrigin                   string
@@ -46,6 +51,8 @@ Method CheckRepositoryPerformance conducts a rudimentary repository test to gaug
 Typically, this is only run when troubleshooting performance.
 
 This is a non-destructive test.
+If ctx is done before the test completes, the test stops early:
+the rows inserted so far are reported along with the error.
 
 Input
   - ctx: A context to control lifecycle.
@@ -65,17 +72,33 @@ func (client *Szdiagnostic) CheckRepositoryPerformance(ctx context.Context, seco
 	if client.isTrace {
 		client.traceEntry(1, secondsToRun)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(2, secondsToRun, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(2, secondsToRun, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.CheckRepositoryPerformanceResult
+	err = client.Faults.Inject(ctx, "checkRepositoryPerformance")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.CheckRepositoryPerformance(ctx, secondsToRun)
+		} else {
+			result = client.CheckRepositoryPerformanceResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8001,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -96,15 +119,26 @@ func (client *Szdiagnostic) Destroy(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(5)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(6, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(6, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	err = client.Faults.Inject(ctx, "destroy")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8002,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -131,19 +165,35 @@ func (client *Szdiagnostic) GetFeature(ctx context.Context, featureID int64) (st
 	if client.isTrace {
 		client.traceEntry(9, featureID)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(10, featureID, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(10, featureID, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetFeatureResult
+	err = client.Faults.Inject(ctx, "getFeature")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetFeature(ctx, featureID)
+		} else {
+			result = client.GetFeatureResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"featureID": strconv.FormatInt(featureID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8004,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -169,17 +219,38 @@ func (client *Szdiagnostic) GetRepositoryInfo(ctx context.Context) (string, erro
 	if client.isTrace {
 		client.traceEntry(7)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(8, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(8, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetRepositoryInfoResult
+	err = client.Faults.Inject(ctx, "getRepositoryInfo")
+	if err == nil {
+		if len(client.Settings) > 0 {
+			aRepository := client.Repository
+			if aRepository == nil {
+				aRepository = &repository.Repository{}
+			}
+
+			result, err = aRepository.GetRepositoryInfo(ctx, client.Settings)
+		} else {
+			result = client.GetRepositoryInfoResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8003,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -204,15 +275,31 @@ func (client *Szdiagnostic) PurgeRepository(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(17)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(18, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(18, err, client.getClock().Now().Sub(entryTime)) }()
+	}
+
+	err = client.Faults.Inject(ctx, "purgeRepository")
+	if err == nil {
+		if client.Repository != nil {
+			err = client.Repository.PurgeRepository(ctx)
+		}
 	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8007,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -251,9 +338,11 @@ func (client *Szdiagnostic) RegisterObserver(ctx context.Context, observer obser
 	if client.isTrace {
 		client.traceEntry(703, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(704, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers == nil {
@@ -267,7 +356,16 @@ func (client *Szdiagnostic) RegisterObserver(ctx context.Context, observer obser
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8702,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -287,9 +385,9 @@ func (client *Szdiagnostic) SetLogLevel(ctx context.Context, logLevelName string
 	if client.isTrace {
 		client.traceEntry(705, logLevelName)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(706, logLevelName, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if !logging.IsValidLogLevelName(logLevelName) {
@@ -304,7 +402,16 @@ func (client *Szdiagnostic) SetLogLevel(ctx context.Context, logLevelName string
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8703,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -336,9 +443,11 @@ func (client *Szdiagnostic) UnregisterObserver(ctx context.Context, observer obs
 	if client.isTrace {
 		client.traceEntry(707, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(708, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers != nil {
@@ -350,7 +459,7 @@ func (client *Szdiagnostic) UnregisterObserver(ctx context.Context, observer obs
 			"observerID": observer.GetObserverID(ctx),
 		}
 
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
+		helper.Notify(ctx, client.getClock(), client.observers, client.observerOrigin, ComponentID, 8704, err, details)
 		err = client.observers.UnregisterObserver(ctx, observer)
 
 		if !client.observers.HasObservers(ctx) {
@@ -365,6 +474,17 @@ func (client *Szdiagnostic) UnregisterObserver(ctx context.Context, observer obs
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// --- Time -------------------------------------------------------------------
+
+// Get the clock of the client, the real clock if none is set.
+func (client *Szdiagnostic) getClock() clock.Clock {
+	if client.Clock == nil {
+		return clock.Real{}
+	}
+
+	return client.Clock
+}
+
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-helpers/env"
 	"github.com/senzing-garage/go-helpers/record"
 	"github.com/senzing-garage/go-helpers/truthset"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
 	"github.com/senzing-garage/sz-sdk-go-mock/szabstractfactory"
 	"github.com/senzing-garage/sz-sdk-go-mock/szdiagnostic"
 	"github.com/senzing-garage/sz-sdk-go-mock/testdata"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,6 +37,7 @@ const (
 	badFeatureID    = int64(-1)
 	badLogLevelName = "BadLogLevelName"
 	badSecondsToRun = -1
+	badSettings     = "}{"
 )
 
 var (
@@ -58,6 +62,34 @@ func TestSzdiagnostic_CheckRepositoryPerformance(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzdiagnostic_CheckRepositoryPerformance_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szDiagnostic := &szdiagnostic.Szdiagnostic{
+		Repository: &repository.Repository{InsertLatency: 250 * time.Millisecond},
+	}
+	actual, err := szDiagnostic.CheckRepositoryPerformance(ctx, 1)
+	require.NoError(test, err)
+	assert.Regexp(test, `"numRecordsInserted":[34],`, actual)
+
+	_, err = szDiagnostic.CheckRepositoryPerformance(ctx, badSecondsToRun)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSzdiagnostic_CheckRepositoryPerformance_canceled(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithTimeout(test.Context(), 50*time.Millisecond)
+
+	defer cancel()
+
+	szDiagnostic := &szdiagnostic.Szdiagnostic{
+		Repository: &repository.Repository{InsertLatency: 20 * time.Millisecond},
+	}
+	actual, err := szDiagnostic.CheckRepositoryPerformance(ctx, 10)
+	require.ErrorContains(test, err, context.DeadlineExceeded.Error())
+	assert.Regexp(test, `"numRecordsInserted":[123],`, actual)
+}
+
 func TestSzdiagnostic_GetRepositoryInfo(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
@@ -67,6 +99,30 @@ func TestSzdiagnostic_GetRepositoryInfo(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzdiagnostic_GetRepositoryInfo_withSettings(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aRepository := &repository.Repository{}
+	szDiagnostic := &szdiagnostic.Szdiagnostic{
+		Repository: aRepository,
+		Settings:   `{"SQL": {"CONNECTION": "sqlite3://na:na@/tmp/sqlite/G2C.db"}}`,
+	}
+	_, err := aRepository.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	actual, err := szDiagnostic.GetRepositoryInfo(ctx)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"dataStores":[{"id":"CORE","location":"/tmp/sqlite/G2C.db","recordCount":1,"type":"sqlite3"}]}`,
+		actual,
+	)
+
+	szDiagnostic.Settings = badSettings
+	_, err = szDiagnostic.GetRepositoryInfo(ctx)
+	require.ErrorIs(test, err, szerror.ErrSz)
+}
+
 func TestSzdiagnostic_GetFeature(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
@@ -85,6 +141,53 @@ func TestSzdiagnostic_GetFeature(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzdiagnostic_GetFeature_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aRepository := &repository.Repository{}
+	szDiagnostic := &szdiagnostic.Szdiagnostic{Repository: aRepository}
+	_, err := aRepository.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	actual, err := szDiagnostic.GetFeature(ctx, 1)
+	require.NoError(test, err)
+	assert.JSONEq(
+		test,
+		`{"LIB_FEAT_ID":1,"FTYPE_CODE":"NAME","ELEMENTS":[{"FELEM_CODE":"FULL_NAME","FELEM_VALUE":"BOB SMITH"}]}`,
+		actual,
+	)
+
+	_, err = szDiagnostic.GetFeature(ctx, badFeatureID)
+	require.ErrorIs(test, err, szerror.ErrSz)
+}
+
+func TestSzdiagnostic_PurgeRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szDiagnostic := getTestObject(test)
+	err := szDiagnostic.PurgeRepository(ctx)
+	require.NoError(test, err)
+}
+
+func TestSzdiagnostic_PurgeRepository_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	aRepository := &repository.Repository{GuardPurge: true}
+	szDiagnostic := &szdiagnostic.Szdiagnostic{Repository: aRepository}
+	_, err := aRepository.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "BOB SMITH"}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	err = szDiagnostic.PurgeRepository(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	aRepository.AllowPurge = true
+	err = szDiagnostic.PurgeRepository(ctx)
+	require.NoError(test, err)
+
+	_, err = aRepository.GetRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
 // ----------------------------------------------------------------------------
 // Logging and observing
 // ----------------------------------------------------------------------------
@@ -203,6 +306,7 @@ func getSzAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 
 	return result
@@ -7,26 +7,39 @@ package szengine
 import (
 	"context"
 	"strconv"
-	"time"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
-	"github.com/senzing-garage/go-observing/notifier"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szengine"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 )
 
+/*
+Szengine is the mock implementation of the [senzing.SzEngine] interface.
+
+If ValidateRecords is set, AddRecord and GetRecordPreview reject record definitions
+the native Senzing binary would reject, including data source codes not listed in DataSources.
+If DataSources is empty, the data sources of the default configuration, TEST and SEARCH, are used.
+
+[senzing.SzEngine]: https://pkg.go.dev/github.com/senzing-garage/sz-sdk-go/senzing#SzEngine
+*/
 type Szengine struct {
 	AddRecordResult                         string
+	Clock                                   clock.Clock
 	CountRedoRecordsResult                  int64
+	DataSources                             []string
 	DeleteRecordResult                      string
 	ExportConfigResult                      string
 	ExportCsvEntityReportResult             uintptr
 	ExportJSONEntityReportResult            uintptr
+	Faults                                  *faults.Injector
 	FetchNextResult                         string
 	FindInterestingEntitiesByEntityIDResult string
 	FindInterestingEntitiesByRecordIDResult string
@@ -50,7 +63,9 @@ type Szengine struct {
 	ProcessRedoRecordResult                 string
 	ReevaluateEntityResult                  string
 	ReevaluateRecordResult                  string
+	Repository                              *repository.Repository
 	SearchByAttributesResult                string
+	ValidateRecords                         bool
 	WhyEntitiesResult                       string
 	WhyRecordInEntityResult                 string
 	WhyRecordsResult                        string
@@ -86,7 +101,10 @@ Input
   - flags: Flags used to control information returned.
 
 Output
-  - A JSON document containing metadata as specified by the flags.
+  - If flags include senzing.SzWithInfo, a JSON document listing the entities the operation changed;
+    otherwise an empty string.
+    Example: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "AFFECTED_ENTITIES": [{"ENTITY_ID": 1}],
+    "INTERESTING_ENTITIES": {"ENTITIES": []}}`
 */
 func (client *Szengine) AddRecord(
 	ctx context.Context,
@@ -103,14 +121,34 @@ func (client *Szengine) AddRecord(
 	if client.isTrace {
 		client.traceEntry(1, dataSourceCode, recordID, recordDefinition, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(2, dataSourceCode, recordID, recordDefinition, flags, result, err, time.Since(entryTime))
+			client.traceExit(
+				2,
+				dataSourceCode,
+				recordID,
+				recordDefinition,
+				flags,
+				result,
+				err,
+				client.getClock().Now().Sub(entryTime),
+			)
 		}()
 	}
 
-	result = client.AddRecordResult
+	err = client.Faults.Inject(ctx, "addRecord")
+	if err == nil {
+		err = client.validateRecord(dataSourceCode, recordID, recordDefinition)
+	}
+
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.AddRecord(ctx, dataSourceCode, recordID, recordDefinition, flags)
+		} else {
+			result = client.AddRecordResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -119,7 +157,16 @@ func (client *Szengine) AddRecord(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8001,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -142,15 +189,31 @@ func (client *Szengine) CloseExportReport(ctx context.Context, exportHandle uint
 	if client.isTrace {
 		client.traceEntry(5, exportHandle)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
+
+		defer func() { client.traceExit(6, exportHandle, err, client.getClock().Now().Sub(entryTime)) }()
+	}
 
-		defer func() { client.traceExit(6, exportHandle, err, time.Since(entryTime)) }()
+	err = client.Faults.Inject(ctx, "closeExportReport")
+	if err == nil {
+		if client.Repository != nil {
+			err = client.Repository.CloseExportReport(ctx, exportHandle)
+		}
 	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8002,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -178,17 +241,33 @@ func (client *Szengine) CountRedoRecords(ctx context.Context) (int64, error) {
 	if client.isTrace {
 		client.traceEntry(7)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(8, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(8, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.CountRedoRecordsResult
+	err = client.Faults.Inject(ctx, "countRedoRecords")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.CountRedoRecords(ctx)
+		} else {
+			result = client.CountRedoRecordsResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8003,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -209,7 +288,10 @@ Input
   - flags: Flags used to control information returned.
 
 Output
-  - A JSON document containing metadata as specified by the flags.
+  - If flags include senzing.SzWithInfo, a JSON document listing the entities the operation changed;
+    otherwise an empty string.
+    Example: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "AFFECTED_ENTITIES": [{"ENTITY_ID": 1}],
+    "INTERESTING_ENTITIES": {"ENTITIES": []}}`
 */
 func (client *Szengine) DeleteRecord(
 	ctx context.Context,
@@ -225,12 +307,21 @@ func (client *Szengine) DeleteRecord(
 	if client.isTrace {
 		client.traceEntry(9, dataSourceCode, recordID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(10, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(10, dataSourceCode, recordID, flags, result, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
-	result = client.DeleteRecordResult
+	err = client.Faults.Inject(ctx, "deleteRecord")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.DeleteRecord(ctx, dataSourceCode, recordID, flags)
+		} else {
+			result = client.DeleteRecordResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -239,7 +330,16 @@ func (client *Szengine) DeleteRecord(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8004,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -260,15 +360,26 @@ func (client *Szengine) Destroy(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(11)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(12, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(12, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	err = client.Faults.Inject(ctx, "destroy")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8005, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8005,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -304,19 +415,37 @@ func (client *Szengine) ExportCsvEntityReport(ctx context.Context, csvColumnList
 	if client.isTrace {
 		client.traceEntry(13, csvColumnList, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(14, csvColumnList, flags, result, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(14, csvColumnList, flags, result, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
-	result = client.ExportCsvEntityReportResult
+	err = client.Faults.Inject(ctx, "exportCsvEntityReport")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.ExportCsvEntityReport(ctx, csvColumnList, flags)
+		} else {
+			result = client.ExportCsvEntityReportResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"flags": strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8006, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8006,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -354,9 +483,23 @@ func (client *Szengine) ExportCsvEntityReportIterator(
 		if client.isTrace {
 			client.traceEntry(15, csvColumnList, flags)
 
-			entryTime := time.Now()
+			entryTime := client.getClock().Now()
 
-			defer func() { client.traceExit(16, csvColumnList, flags, err, time.Since(entryTime)) }()
+			defer func() { client.traceExit(16, csvColumnList, flags, err, client.getClock().Now().Sub(entryTime)) }()
+		}
+
+		err = client.Faults.Inject(ctx, "exportCsvEntityReportIterator")
+		if err != nil {
+			stringFragmentChannel <- senzing.StringFragment{Error: err}
+		} else if client.Repository != nil {
+			var exportHandle uintptr
+
+			exportHandle, err = client.Repository.ExportCsvEntityReport(ctx, csvColumnList, flags)
+			if err != nil {
+				stringFragmentChannel <- senzing.StringFragment{Error: err}
+			} else {
+				err = client.streamExport(ctx, exportHandle, stringFragmentChannel)
+			}
 		}
 
 		if client.observers != nil {
@@ -364,7 +507,16 @@ func (client *Szengine) ExportCsvEntityReportIterator(
 				details := map[string]string{
 					"flags": strconv.FormatInt(flags, baseTen),
 				}
-				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
+				helper.Notify(
+					ctx,
+					client.getClock(),
+					client.observers,
+					client.observerOrigin,
+					ComponentID,
+					8007,
+					err,
+					details,
+				)
 			}()
 		}
 	}()
@@ -397,19 +549,35 @@ func (client *Szengine) ExportJSONEntityReport(ctx context.Context, flags int64)
 	if client.isTrace {
 		client.traceEntry(17, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(18, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(18, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.ExportJSONEntityReportResult
+	err = client.Faults.Inject(ctx, "exportJSONEntityReport")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.ExportJSONEntityReport(ctx, flags)
+		} else {
+			result = client.ExportJSONEntityReportResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"flags": strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8008,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -441,15 +609,38 @@ func (client *Szengine) ExportJSONEntityReportIterator(ctx context.Context, flag
 		if client.isTrace {
 			client.traceEntry(19, flags)
 
-			entryTime := time.Now()
+			entryTime := client.getClock().Now()
+
+			defer func() { client.traceExit(20, flags, err, client.getClock().Now().Sub(entryTime)) }()
+		}
 
-			defer func() { client.traceExit(20, flags, err, time.Since(entryTime)) }()
+		err = client.Faults.Inject(ctx, "exportJSONEntityReportIterator")
+		if err != nil {
+			stringFragmentChannel <- senzing.StringFragment{Error: err}
+		} else if client.Repository != nil {
+			var exportHandle uintptr
+
+			exportHandle, err = client.Repository.ExportJSONEntityReport(ctx, flags)
+			if err != nil {
+				stringFragmentChannel <- senzing.StringFragment{Error: err}
+			} else {
+				err = client.streamExport(ctx, exportHandle, stringFragmentChannel)
+			}
 		}
 
 		if client.observers != nil {
 			go func() {
 				details := map[string]string{}
-				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8009, err, details)
+				helper.Notify(
+					ctx,
+					client.getClock(),
+					client.observers,
+					client.observerOrigin,
+					ComponentID,
+					8009,
+					err,
+					details,
+				)
 			}()
 		}
 	}()
@@ -486,17 +677,33 @@ func (client *Szengine) FetchNext(ctx context.Context, exportHandle uintptr) (st
 	if client.isTrace {
 		client.traceEntry(21, exportHandle)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(22, exportHandle, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(22, exportHandle, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.FetchNextResult
+	err = client.Faults.Inject(ctx, "fetchNext")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FetchNext(ctx, exportHandle)
+		} else {
+			result = client.FetchNextResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8010, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8010,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -529,12 +736,19 @@ func (client *Szengine) FindInterestingEntitiesByEntityID(
 	if client.isTrace {
 		client.traceEntry(23, entityID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(24, entityID, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(24, entityID, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.FindInterestingEntitiesByEntityIDResult
+	err = client.Faults.Inject(ctx, "findInterestingEntitiesByEntityID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FindInterestingEntitiesByEntityID(ctx, entityID, flags)
+		} else {
+			result = client.FindInterestingEntitiesByEntityIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -542,7 +756,16 @@ func (client *Szengine) FindInterestingEntitiesByEntityID(
 				"entityID": formatEntityID(entityID),
 				"flags":    strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8011, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8011,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -577,14 +800,21 @@ func (client *Szengine) FindInterestingEntitiesByRecordID(
 	if client.isTrace {
 		client.traceEntry(25, dataSourceCode, recordID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(26, dataSourceCode, recordID, flags, result, err, time.Since(entryTime))
+			client.traceExit(26, dataSourceCode, recordID, flags, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.FindInterestingEntitiesByRecordIDResult
+	err = client.Faults.Inject(ctx, "findInterestingEntitiesByRecordID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FindInterestingEntitiesByRecordID(ctx, dataSourceCode, recordID, flags)
+		} else {
+			result = client.FindInterestingEntitiesByRecordIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -593,7 +823,16 @@ func (client *Szengine) FindInterestingEntitiesByRecordID(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8012, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8012,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -635,7 +874,7 @@ func (client *Szengine) FindNetworkByEntityID(
 	if client.isTrace {
 		client.traceEntry(27, entityIDs, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
 			client.traceExit(
@@ -647,12 +886,26 @@ func (client *Szengine) FindNetworkByEntityID(
 				flags,
 				result,
 				err,
-				time.Since(entryTime),
+				client.getClock().Now().Sub(entryTime),
 			)
 		}()
 	}
 
-	result = client.FindNetworkByEntityIDResult
+	err = client.Faults.Inject(ctx, "findNetworkByEntityID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FindNetworkByEntityID(
+				ctx,
+				entityIDs,
+				maxDegrees,
+				buildOutDegrees,
+				buildOutMaxEntities,
+				flags,
+			)
+		} else {
+			result = client.FindNetworkByEntityIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -660,7 +913,16 @@ func (client *Szengine) FindNetworkByEntityID(
 				"entityIDs": entityIDs,
 				"flags":     strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8013, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8013,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -702,7 +964,7 @@ func (client *Szengine) FindNetworkByRecordID(
 	if client.isTrace {
 		client.traceEntry(29, recordKeys, maxDegrees, buildOutDegrees, buildOutMaxEntities, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
 			client.traceExit(
@@ -714,12 +976,26 @@ func (client *Szengine) FindNetworkByRecordID(
 				flags,
 				result,
 				err,
-				time.Since(entryTime),
+				client.getClock().Now().Sub(entryTime),
 			)
 		}()
 	}
 
-	result = client.FindNetworkByRecordIDResult
+	err = client.Faults.Inject(ctx, "findNetworkByRecordID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FindNetworkByRecordID(
+				ctx,
+				recordKeys,
+				maxDegrees,
+				buildOutDegrees,
+				buildOutMaxEntities,
+				flags,
+			)
+		} else {
+			result = client.FindNetworkByRecordIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -727,7 +1003,16 @@ func (client *Szengine) FindNetworkByRecordID(
 				"recordKeys": recordKeys,
 				"flags":      strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8014, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8014,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -772,15 +1057,30 @@ func (client *Szengine) FindPathByEntityID(
 	if client.isTrace {
 		client.traceEntry(31, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
 			client.traceExit(32, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources,
-				flags, result, err, time.Since(entryTime))
+				flags, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.FindPathByEntityIDResult
+	err = client.Faults.Inject(ctx, "findPathByEntityID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FindPathByEntityID(
+				ctx,
+				startEntityID,
+				endEntityID,
+				maxDegrees,
+				avoidEntityIDs,
+				requiredDataSources,
+				flags,
+			)
+		} else {
+			result = client.FindPathByEntityIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -791,7 +1091,16 @@ func (client *Szengine) FindPathByEntityID(
 				"requiredDataSources": requiredDataSources,
 				"flags":               strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8015, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8015,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -849,7 +1158,7 @@ func (client *Szengine) FindPathByRecordID(
 		client.traceEntry(33, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees,
 			avoidRecordKeys, requiredDataSources, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
 			client.traceExit(
@@ -864,12 +1173,29 @@ func (client *Szengine) FindPathByRecordID(
 				flags,
 				result,
 				err,
-				time.Since(entryTime),
+				client.getClock().Now().Sub(entryTime),
 			)
 		}()
 	}
 
-	result = client.FindPathByRecordIDResult
+	err = client.Faults.Inject(ctx, "findPathByRecordID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.FindPathByRecordID(
+				ctx,
+				startDataSourceCode,
+				startRecordID,
+				endDataSourceCode,
+				endRecordID,
+				maxDegrees,
+				avoidRecordKeys,
+				requiredDataSources,
+				flags,
+			)
+		} else {
+			result = client.FindPathByRecordIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -882,7 +1208,16 @@ func (client *Szengine) FindPathByRecordID(
 				"requiredDataSources": requiredDataSources,
 				"flags":               strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8016, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8016,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -909,17 +1244,29 @@ func (client *Szengine) GetActiveConfigID(ctx context.Context) (int64, error) {
 	if client.isTrace {
 		client.traceEntry(35)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(36, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(36, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetActiveConfigIDResult
+	err = client.Faults.Inject(ctx, "getActiveConfigID")
+	if err == nil {
+		result = client.GetActiveConfigIDResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8017, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8017,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -947,12 +1294,19 @@ func (client *Szengine) GetEntityByEntityID(ctx context.Context, entityID int64,
 	if client.isTrace {
 		client.traceEntry(37, entityID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(38, entityID, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(38, entityID, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetEntityByEntityIDResult
+	err = client.Faults.Inject(ctx, "getEntityByEntityID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetEntityByEntityID(ctx, entityID, flags)
+		} else {
+			result = client.GetEntityByEntityIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -960,7 +1314,16 @@ func (client *Szengine) GetEntityByEntityID(ctx context.Context, entityID int64,
 				"entityID": formatEntityID(entityID),
 				"flags":    strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8018, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8018,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -993,14 +1356,21 @@ func (client *Szengine) GetEntityByRecordID(
 	if client.isTrace {
 		client.traceEntry(39, dataSourceCode, recordID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(40, dataSourceCode, recordID, flags, result, err, time.Since(entryTime))
+			client.traceExit(40, dataSourceCode, recordID, flags, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.GetEntityByRecordIDResult
+	err = client.Faults.Inject(ctx, "getEntityByRecordID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetEntityByRecordID(ctx, dataSourceCode, recordID, flags)
+		} else {
+			result = client.GetEntityByRecordIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1009,7 +1379,16 @@ func (client *Szengine) GetEntityByRecordID(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8019, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8019,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1045,14 +1424,21 @@ func (client *Szengine) GetRecord(
 	if client.isTrace {
 		client.traceEntry(45, dataSourceCode, recordID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(46, dataSourceCode, recordID, flags, result, err, time.Since(entryTime))
+			client.traceExit(46, dataSourceCode, recordID, flags, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.GetRecordResult
+	err = client.Faults.Inject(ctx, "getRecord")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetRecord(ctx, dataSourceCode, recordID, flags)
+		} else {
+			result = client.GetRecordResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1061,7 +1447,16 @@ func (client *Szengine) GetRecord(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8020, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8020,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1090,21 +1485,41 @@ func (client *Szengine) GetRecordPreview(ctx context.Context, recordDefinition s
 	if client.isTrace {
 		client.traceEntry(77, recordDefinition, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(78, recordDefinition, flags, result, err, time.Since(entryTime))
+			client.traceExit(78, recordDefinition, flags, result, err, client.getClock().Now().Sub(entryTime))
 		}()
 	}
 
-	result = client.GetRecordPreviewResult
+	err = client.Faults.Inject(ctx, "getRecordPreview")
+	if err == nil {
+		err = client.validateRecord("", "", recordDefinition)
+	}
+
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetRecordPreview(ctx, recordDefinition, flags)
+		} else {
+			result = client.GetRecordPreviewResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"flags": strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8035, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8035,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1139,17 +1554,33 @@ func (client *Szengine) GetRedoRecord(ctx context.Context) (string, error) {
 	if client.isTrace {
 		client.traceEntry(47)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(48, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(48, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetRedoRecordResult
+	err = client.Faults.Inject(ctx, "getRedoRecord")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetRedoRecord(ctx)
+		} else {
+			result = client.GetRedoRecordResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8021, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8021,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1178,17 +1609,33 @@ func (client *Szengine) GetStats(ctx context.Context) (string, error) {
 	if client.isTrace {
 		client.traceEntry(49)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(50, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(50, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetStatsResult
+	err = client.Faults.Inject(ctx, "getStats")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetStats(ctx)
+		} else {
+			result = client.GetStatsResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8022, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8022,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1222,12 +1669,19 @@ func (client *Szengine) GetVirtualEntityByRecordID(
 	if client.isTrace {
 		client.traceEntry(51, recordKeys, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(52, recordKeys, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(52, recordKeys, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetVirtualEntityByRecordIDResult
+	err = client.Faults.Inject(ctx, "getVirtualEntityByRecordID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.GetVirtualEntityByRecordID(ctx, recordKeys, flags)
+		} else {
+			result = client.GetVirtualEntityByRecordIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1235,7 +1689,16 @@ func (client *Szengine) GetVirtualEntityByRecordID(
 				"recordKeys": recordKeys,
 				"flags":      strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8023, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8023,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1262,12 +1725,19 @@ func (client *Szengine) HowEntityByEntityID(ctx context.Context, entityID int64,
 	if client.isTrace {
 		client.traceEntry(53, entityID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(54, entityID, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(54, entityID, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.HowEntityByEntityIDResult
+	err = client.Faults.Inject(ctx, "howEntityByEntityID")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.HowEntityByEntityID(ctx, entityID, flags)
+		} else {
+			result = client.HowEntityByEntityIDResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1275,7 +1745,16 @@ func (client *Szengine) HowEntityByEntityID(ctx context.Context, entityID int64,
 				"entityID": formatEntityID(entityID),
 				"flags":    strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8024, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8024,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1297,15 +1776,26 @@ func (client *Szengine) PrimeEngine(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(57)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(58, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(58, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	err = client.Faults.Inject(ctx, "primeEngine")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8026, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8026,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1323,9 +1813,14 @@ See also getRedoRecord() countRedoRecords().
 
 Input
   - ctx: A context to control lifecycle.
+  - redoRecord: A redo record as returned by getRedoRecord().
+  - flags: Flags used to control information returned.
 
 Output
-  - A JSON document.
+  - If flags include senzing.SzWithInfo, a JSON document listing the entities the operation changed;
+    otherwise an empty string.
+    Example: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "AFFECTED_ENTITIES": [{"ENTITY_ID": 1}],
+    "INTERESTING_ENTITIES": {"ENTITIES": []}}`
 */
 func (client *Szengine) ProcessRedoRecord(ctx context.Context, redoRecord string, flags int64) (string, error) {
 	var (
@@ -1336,19 +1831,35 @@ func (client *Szengine) ProcessRedoRecord(ctx context.Context, redoRecord string
 	if client.isTrace {
 		client.traceEntry(59, redoRecord, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(60, redoRecord, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(60, redoRecord, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.ProcessRedoRecordResult
+	err = client.Faults.Inject(ctx, "processRedoRecord")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.ProcessRedoRecord(ctx, redoRecord, flags)
+		} else {
+			result = client.ProcessRedoRecordResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"flags": strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8027, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8027,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1366,6 +1877,12 @@ Input
   - ctx: A context to control lifecycle.
   - entityID: The unique identifier of an entity.
   - flags: Flags used to control information returned.
+
+Output
+  - If flags include senzing.SzWithInfo, a JSON document listing the entities the operation changed;
+    otherwise an empty string.
+    Example: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "AFFECTED_ENTITIES": [{"ENTITY_ID": 1}],
+    "INTERESTING_ENTITIES": {"ENTITIES": []}}`
 */
 func (client *Szengine) ReevaluateEntity(ctx context.Context, entityID int64, flags int64) (string, error) {
 	var (
@@ -1376,12 +1893,19 @@ func (client *Szengine) ReevaluateEntity(ctx context.Context, entityID int64, fl
 	if client.isTrace {
 		client.traceEntry(61, entityID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(62, entityID, flags, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(62, entityID, flags, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.ReevaluateEntityResult
+	err = client.Faults.Inject(ctx, "reevaluateEntity")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.ReevaluateEntity(ctx, entityID, flags)
+		} else {
+			result = client.ReevaluateEntityResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1389,7 +1913,16 @@ func (client *Szengine) ReevaluateEntity(ctx context.Context, entityID int64, fl
 				"entityID": formatEntityID(entityID),
 				"flags":    strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8028, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8028,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1408,6 +1941,12 @@ Input
   - dataSourceCode: Identifies the provenance of the data.
   - recordID: The unique identifier within the records of the same data source.
   - flags: Flags used to control information returned.
+
+Output
+  - If flags include senzing.SzWithInfo, a JSON document listing the entities the operation changed;
+    otherwise an empty string.
+    Example: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "AFFECTED_ENTITIES": [{"ENTITY_ID": 1}],
+    "INTERESTING_ENTITIES": {"ENTITIES": []}}`
 */
 func (client *Szengine) ReevaluateRecord(
 	ctx context.Context,
@@ -1423,12 +1962,21 @@ func (client *Szengine) ReevaluateRecord(
 	if client.isTrace {
 		client.traceEntry(63, dataSourceCode, recordID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(64, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(64, dataSourceCode, recordID, flags, result, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
-	result = client.ReevaluateRecordResult
+	err = client.Faults.Inject(ctx, "reevaluateRecord")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.ReevaluateRecord(ctx, dataSourceCode, recordID, flags)
+		} else {
+			result = client.ReevaluateRecordResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1437,7 +1985,16 @@ func (client *Szengine) ReevaluateRecord(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8029, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8029,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1475,12 +2032,21 @@ func (client *Szengine) SearchByAttributes(
 	if client.isTrace {
 		client.traceEntry(69, attributes, searchProfile, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(70, attributes, searchProfile, flags, result, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(70, attributes, searchProfile, flags, result, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
-	result = client.SearchByAttributesResult
+	err = client.Faults.Inject(ctx, "searchByAttributes")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.SearchByAttributes(ctx, attributes, searchProfile, flags)
+		} else {
+			result = client.SearchByAttributesResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1489,7 +2055,16 @@ func (client *Szengine) SearchByAttributes(
 				"searchProfile": searchProfile,
 				"flags":         strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8031, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8031,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1522,12 +2097,21 @@ func (client *Szengine) WhyEntities(
 	if client.isTrace {
 		client.traceEntry(71, entityID1, entityID2, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(72, entityID1, entityID2, flags, result, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(72, entityID1, entityID2, flags, result, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
-	result = client.WhyEntitiesResult
+	err = client.Faults.Inject(ctx, "whyEntities")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.WhyEntities(ctx, entityID1, entityID2, flags)
+		} else {
+			result = client.WhyEntitiesResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1536,7 +2120,16 @@ func (client *Szengine) WhyEntities(
 				"entityID2": formatEntityID(entityID2),
 				"flags":     strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8032, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8032,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1569,12 +2162,21 @@ func (client *Szengine) WhyRecordInEntity(
 	if client.isTrace {
 		client.traceEntry(73, dataSourceCode, recordID, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(74, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(74, dataSourceCode, recordID, flags, result, err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
-	result = client.WhyRecordInEntityResult
+	err = client.Faults.Inject(ctx, "whyRecordInEntity")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.WhyRecordInEntity(ctx, dataSourceCode, recordID, flags)
+		} else {
+			result = client.WhyRecordInEntityResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1583,7 +2185,16 @@ func (client *Szengine) WhyRecordInEntity(
 				"recordID":       recordID,
 				"flags":          strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8033, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8033,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1620,7 +2231,7 @@ func (client *Szengine) WhyRecords(
 	if client.isTrace {
 		client.traceEntry(75, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
 			client.traceExit(
@@ -1632,12 +2243,26 @@ func (client *Szengine) WhyRecords(
 				flags,
 				result,
 				err,
-				time.Since(entryTime),
+				client.getClock().Now().Sub(entryTime),
 			)
 		}()
 	}
 
-	result = client.WhyRecordsResult
+	err = client.Faults.Inject(ctx, "whyRecords")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.WhyRecords(
+				ctx,
+				dataSourceCode1,
+				recordID1,
+				dataSourceCode2,
+				recordID2,
+				flags,
+			)
+		} else {
+			result = client.WhyRecordsResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1648,7 +2273,16 @@ func (client *Szengine) WhyRecords(
 				"recordID2":       recordID2,
 				"flags":           strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8034, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8034,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1688,14 +2322,30 @@ func (client *Szengine) WhySearch(
 	if client.isTrace {
 		client.traceEntry(69, attributes, entityID, searchProfile, flags)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		defer func() {
-			client.traceExit(70, attributes, entityID, searchProfile, flags, result, err, time.Since(entryTime))
+			client.traceExit(
+				70,
+				attributes,
+				entityID,
+				searchProfile,
+				flags,
+				result,
+				err,
+				client.getClock().Now().Sub(entryTime),
+			)
 		}()
 	}
 
-	result = client.WhySearchResult
+	err = client.Faults.Inject(ctx, "whySearch")
+	if err == nil {
+		if client.Repository != nil {
+			result, err = client.Repository.WhySearch(ctx, attributes, entityID, searchProfile, flags)
+		} else {
+			result = client.WhySearchResult
+		}
+	}
 
 	if client.observers != nil {
 		go func() {
@@ -1705,7 +2355,16 @@ func (client *Szengine) WhySearch(
 				"searchProfile": searchProfile,
 				"flags":         strconv.FormatInt(flags, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8031, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8031,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1735,6 +2394,7 @@ func (client *Szengine) GetObserverOrigin(ctx context.Context) string {
 Method Initialize initializes the SzEngine object.
 
 It must be called prior to any other calls.
+Reinitializing ends the outage of Faults, if any, as it does for every client sharing Faults.
 
 Input
   - ctx: A context to control lifecycle.
@@ -1744,19 +2404,30 @@ func (client *Szengine) Reinitialize(ctx context.Context, configID int64) error
 	var err error
 
 	if client.isTrace {
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
 		client.traceEntry(65, configID)
 
-		defer func() { client.traceExit(66, configID, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(66, configID, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	client.Faults.EndOutage()
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"configID": strconv.FormatInt(configID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8030, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8030,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1776,9 +2447,11 @@ func (client *Szengine) RegisterObserver(ctx context.Context, observer observer.
 	if client.isTrace {
 		client.traceEntry(703, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(704, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers == nil {
@@ -1792,7 +2465,16 @@ func (client *Szengine) RegisterObserver(ctx context.Context, observer observer.
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8702,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1812,9 +2494,9 @@ func (client *Szengine) SetLogLevel(ctx context.Context, logLevelName string) er
 	if client.isTrace {
 		client.traceEntry(705, logLevelName)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(706, logLevelName, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if !logging.IsValidLogLevelName(logLevelName) {
@@ -1829,7 +2511,16 @@ func (client *Szengine) SetLogLevel(ctx context.Context, logLevelName string) er
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8703,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -1861,9 +2552,11 @@ func (client *Szengine) UnregisterObserver(ctx context.Context, observer observe
 	if client.isTrace {
 		client.traceEntry(707, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(708, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers != nil {
@@ -1874,7 +2567,7 @@ func (client *Szengine) UnregisterObserver(ctx context.Context, observer observe
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
+		helper.Notify(ctx, client.getClock(), client.observers, client.observerOrigin, ComponentID, 8704, err, details)
 		err = client.observers.UnregisterObserver(ctx, observer)
 
 		if !client.observers.HasObservers(ctx) {
@@ -1889,6 +2582,17 @@ func (client *Szengine) UnregisterObserver(ctx context.Context, observer observe
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// --- Time -------------------------------------------------------------------
+
+// Get the clock of the client, the real clock if none is set.
+func (client *Szengine) getClock() clock.Clock {
+	if client.Clock == nil {
+		return clock.Real{}
+	}
+
+	return client.Clock
+}
+
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
@@ -1910,6 +2614,52 @@ func (client *Szengine) traceExit(errorNumber int, details ...interface{}) {
 	client.getLogger().Log(errorNumber, details...)
 }
 
+// --- Repository -------------------------------------------------------------
+
+// Check a record definition when ValidateRecords is set.
+func (client *Szengine) validateRecord(dataSourceCode string, recordID string, recordDefinition string) error {
+	if !client.ValidateRecords {
+		return nil
+	}
+
+	return repository.ValidateRecord(client.DataSources, dataSourceCode, recordID, recordDefinition)
+}
+
+/*
+Method streamExport sends each line of a repository export to a channel, then closes the export.
+
+Input
+  - ctx: A context to control lifecycle.
+  - exportHandle: A handle created by the attached repository.
+  - stringFragmentChannel: The channel receiving the lines.
+*/
+func (client *Szengine) streamExport(
+	ctx context.Context,
+	exportHandle uintptr,
+	stringFragmentChannel chan senzing.StringFragment,
+) error {
+	defer func() { _ = client.Repository.CloseExportReport(ctx, exportHandle) }()
+
+	for {
+		line, err := client.Repository.FetchNext(ctx, exportHandle)
+		if err != nil {
+			stringFragmentChannel <- senzing.StringFragment{Error: err}
+
+			return wraperror.Errorf(err, wraperror.NoMessage)
+		}
+
+		if len(line) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return wraperror.Errorf(ctx.Err(), wraperror.NoMessage)
+		case stringFragmentChannel <- senzing.StringFragment{Value: line}:
+		}
+	}
+}
+
 func formatEntityID(entityID int64) string {
 	return strconv.FormatInt(entityID, baseTen)
 }
@@ -1119,6 +1119,36 @@ func ExampleSzengine_WhyRecords() {
 	// Output: {"ENTITIES":[{"RESOLVED_ENTITY":{"ENTITY_ID":100001}}...
 }
 
+func ExampleSzengine_WhySearch() {
+	// For more information, visit
+	// https://github.com/senzing-garage/sz-sdk-go-mock/blob/main/szengine/szengine_examples_test.go
+	ctx := context.TODO()
+	szAbstractFactory := getSzAbstractFactory(ctx)
+
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	if err != nil {
+		handleError(err)
+	}
+
+	attributes := `{"NAME_FULL": "BOB SMITH", "SSN_NUMBER": "123-45-6789"}`
+
+	entityID, err := getEntityID(truthset.CustomerRecords["1001"])
+	if err != nil {
+		handleError(err)
+	}
+
+	searchProfile := senzing.SzNoSearchProfile
+	flags := senzing.SzNoFlags
+
+	result, err := szEngine.WhySearch(ctx, attributes, entityID, searchProfile, flags)
+	if err != nil {
+		handleError(err)
+	}
+
+	fmt.Println(jsonutil.Truncate(result, 7))
+	// Output: {"ENTITIES":[{"RESOLVED_ENTITY":{"ENTITY_ID":100001}}...
+}
+
 // ----------------------------------------------------------------------------
 // Logging and observing
 // ----------------------------------------------------------------------------
@@ -12,10 +12,14 @@ import (
 	"github.com/senzing-garage/go-helpers/record"
 	"github.com/senzing-garage/go-helpers/truthset"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
+	"github.com/senzing-garage/sz-sdk-go-mock/random"
+	"github.com/senzing-garage/sz-sdk-go-mock/repository"
 	"github.com/senzing-garage/sz-sdk-go-mock/szabstractfactory"
 	"github.com/senzing-garage/sz-sdk-go-mock/szengine"
 	"github.com/senzing-garage/sz-sdk-go-mock/testdata"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -635,6 +639,323 @@ func TestSzengine_WhyRecords(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_WhySearch(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+	}
+
+	defer func() { deleteRecords(ctx, records) }()
+
+	addRecords(ctx, records)
+
+	szEngine := getTestObject(test)
+	entityID, err := getEntityID(records[0])
+	require.NoError(test, err)
+
+	actual, err := szEngine.WhySearch(ctx, searchAttributes, entityID, searchProfile, senzing.SzWhySearchDefaultFlags)
+	require.NoError(test, err)
+	printActual(test, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Repository
+// ----------------------------------------------------------------------------
+
+func TestSzengine_AddRecord_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	record := truthset.CustomerRecords["1001"]
+
+	actual, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithInfo)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, "AFFECTED_ENTITIES")
+
+	actual, err = szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzEntityIncludeRecordData)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, `"RECORDS"`)
+
+	actual, err = szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.NotContains(test, actual, `"RECORDS"`)
+	assert.NotContains(test, actual, `"RELATED_ENTITIES"`)
+
+	actual, err = szEngine.DeleteRecord(ctx, record.DataSource, record.ID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+
+	_, err = szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+	require.Error(test, err)
+}
+
+func TestSzengine_AddRecord_faults(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := &szengine.Szengine{
+		Faults: &faults.Injector{
+			Chaos:  faults.Chaos{ErrorRate: 1, RetryableShare: 1},
+			Random: random.New(1),
+		},
+		Repository: &repository.Repository{},
+	}
+	record := truthset.CustomerRecords["1001"]
+
+	_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+
+	szEngine.Faults.Chaos = faults.Chaos{}
+	_, err = szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		require.NoError(test, fragment.Error)
+	}
+
+	szEngine.Faults.Chaos = faults.Chaos{ErrorRate: 1}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		require.Error(test, fragment.Error)
+	}
+}
+
+func TestSzengine_Reinitialize_outage(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := &szengine.Szengine{Faults: &faults.Injector{}}
+	require.NoError(test, szEngine.Faults.StartOutage(faults.Outage{Kind: faults.EngineCrash}))
+
+	_, err := szEngine.GetActiveConfigID(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+
+	require.NoError(test, szEngine.Reinitialize(ctx, 0))
+	_, err = szEngine.GetActiveConfigID(ctx)
+	require.NoError(test, err)
+
+	require.NoError(test, (&szengine.Szengine{}).Reinitialize(ctx, 0))
+}
+
+func TestSzengine_AddRecord_validateRecords(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getValidatingTestObject(test)
+	record := truthset.CustomerRecords["1001"]
+
+	testCases := []struct {
+		name             string
+		dataSourceCode   string
+		recordID         string
+		recordDefinition string
+	}{
+		{
+			name:             "badRecordDefinition",
+			dataSourceCode:   record.DataSource,
+			recordID:         record.ID,
+			recordDefinition: badRecordDefinition,
+		},
+		{name: "badDataSourceCode", dataSourceCode: badDataSourceCode, recordID: record.ID, recordDefinition: `{}`},
+		{
+			name:             "conflicting DATA_SOURCE",
+			dataSourceCode:   record.DataSource,
+			recordID:         record.ID,
+			recordDefinition: `{"DATA_SOURCE": "REFERENCE"}`,
+		},
+		{
+			name:             "conflicting RECORD_ID",
+			dataSourceCode:   record.DataSource,
+			recordID:         record.ID,
+			recordDefinition: `{"RECORD_ID": "1002"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := szEngine.AddRecord(
+				ctx,
+				testCase.dataSourceCode,
+				testCase.recordID,
+				testCase.recordDefinition,
+				senzing.SzWithoutInfo,
+			)
+			require.ErrorIs(test, err, szerror.ErrSzBadInput)
+			assert.Empty(test, actual)
+		})
+	}
+
+	_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+}
+
+func TestSzengine_GetRecordPreview_validateRecords(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getValidatingTestObject(test)
+
+	_, err := szEngine.GetRecordPreview(ctx, badRecordDefinition, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = szEngine.GetRecordPreview(ctx, `{"DATA_SOURCE": "`+badDataSourceCode+`"}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+
+	_, err = szEngine.GetRecordPreview(ctx, truthset.CustomerRecords["1001"].JSON, senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestSzengine_FindInterestingEntitiesByRecordID_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	szEngine.Repository.InterestRules = []repository.InterestRule{
+		{Flag: "SHARED_PHONE", SharedFeatures: []string{"PHONE"}},
+	}
+	records := []record.Record{
+		{DataSource: "CUSTOMERS", ID: "I1", JSON: `{"NAME_FULL": "ROBERT SMITH", "PHONE_NUMBER": "702-555-1212"}`},
+		{DataSource: "CUSTOMERS", ID: "I2", JSON: `{"NAME_FULL": "JANE DOE", "PHONE_NUMBER": "702-555-1212"}`},
+	}
+
+	for _, record := range records {
+		_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+		require.NoError(test, err)
+	}
+
+	actual, err := szEngine.FindInterestingEntitiesByRecordID(ctx, "CUSTOMERS", "I1", senzing.SzNoFlags)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, `"FLAGS":["SHARED_PHONE"]`)
+}
+
+func TestSzengine_FindPathByEntityID_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+
+	for _, record := range []record.Record{record1, record2} {
+		_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+		require.NoError(test, err)
+	}
+
+	startEntityID := getStatefulEntityID(ctx, test, szEngine, record1)
+	endEntityID := getStatefulEntityID(ctx, test, szEngine, record2)
+	maxDegrees := int64(1)
+	flags := senzing.SzFindPathDefaultFlags
+
+	actual, err := szEngine.FindPathByEntityID(ctx, startEntityID, endEntityID, maxDegrees, "", "", flags)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, `"ENTITY_PATH_LINKS"`)
+
+	_, err = szEngine.FindPathByEntityID(ctx, startEntityID, endEntityID, maxDegrees, badAvoidEntityIDs, "", flags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = szEngine.FindPathByEntityID(ctx, startEntityID, endEntityID, maxDegrees, "", badRequiredDataSources, flags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+
+	_, err = szEngine.FindPathByRecordID(
+		ctx,
+		record1.DataSource,
+		record1.ID,
+		record2.DataSource,
+		record2.ID,
+		maxDegrees,
+		badAvoidRecordKeys,
+		"",
+		flags,
+	)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSzengine_ExportJSONEntityReportIterator_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	record := truthset.CustomerRecords["1001"]
+	_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	lines := 0
+
+	for result := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzExportDefaultFlags) {
+		require.NoError(test, result.Error)
+		outputln(result.Value)
+
+		lines++
+	}
+
+	assert.Equal(test, 1, lines)
+}
+
+func TestSzengine_GetStats_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	record := truthset.CustomerRecords["1001"]
+	_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	actual, err := szEngine.GetStats(ctx)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, `"addedRecords":1`)
+
+	actual, err = szEngine.GetStats(ctx)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"addedRecords":0`)
+}
+
+func TestSzengine_WhyRecords_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+
+	for _, record := range []record.Record{record1, record2} {
+		_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+		require.NoError(test, err)
+	}
+
+	actual, err := szEngine.WhyRecords(
+		ctx,
+		record1.DataSource,
+		record1.ID,
+		record2.DataSource,
+		record2.ID,
+		senzing.SzWhyRecordsDefaultFlags,
+	)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, `"WHY_ERRULE_CODE"`)
+
+	_, err = szEngine.WhyRecordInEntity(ctx, record1.DataSource, "Z", senzing.SzWhyRecordInEntityDefaultFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestSzengine_WhySearch_withRepository(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	szEngine := getStatefulTestObject(test)
+	record := truthset.CustomerRecords["1001"]
+	_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+
+	entityID := getStatefulEntityID(ctx, test, szEngine, record)
+	attributes := `{"NAME_FULL": "ROBERT SMITH", "DATE_OF_BIRTH": "12/11/1978"}`
+
+	actual, err := szEngine.WhySearch(ctx, attributes, entityID, searchProfile, senzing.SzWhySearchDefaultFlags)
+	require.NoError(test, err)
+	printActual(test, actual)
+	assert.Contains(test, actual, `"MATCH_LEVEL_CODE":"POSSIBLY_SAME"`)
+
+	_, err = szEngine.WhySearch(ctx, attributes, 1, searchProfile, senzing.SzWhySearchDefaultFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
 // ----------------------------------------------------------------------------
 // Logging and observing
 // ----------------------------------------------------------------------------
@@ -810,6 +1131,7 @@ func getSzAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 
 	return result
@@ -852,6 +1174,7 @@ func getSzEngine(ctx context.Context) *szengine.Szengine {
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 	if logLevel == "TRACE" {
 		result.SetObserverOrigin(ctx, observerOrigin)
@@ -868,12 +1191,42 @@ func getSzEngineAsInterface(ctx context.Context) senzing.SzEngine {
 	return getSzEngine(ctx)
 }
 
+func getStatefulEntityID(ctx context.Context, t *testing.T, szEngine *szengine.Szengine, record record.Record) int64 {
+	t.Helper()
+
+	response, err := szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+	require.NoError(t, err)
+
+	getEntityByRecordIDResponse := &GetEntityByRecordIDResponse{}
+	require.NoError(t, json.Unmarshal([]byte(response), getEntityByRecordIDResponse))
+
+	return getEntityByRecordIDResponse.ResolvedEntity.EntityID
+}
+
+func getStatefulTestObject(t *testing.T) *szengine.Szengine {
+	t.Helper()
+
+	return &szengine.Szengine{
+		Repository: &repository.Repository{},
+	}
+}
+
 func getTestObject(t *testing.T) *szengine.Szengine {
 	t.Helper()
 
 	return getSzEngine(t.Context())
 }
 
+func getValidatingTestObject(t *testing.T) *szengine.Szengine {
+	t.Helper()
+
+	result := getSzEngine(t.Context())
+	result.DataSources = []string{"CUSTOMERS", "REFERENCE", "WATCHLIST"}
+	result.ValidateRecords = true
+
+	return result
+}
+
 func handleError(err error) {
 	if err != nil {
 		outputln("Error:", err)
@@ -0,0 +1,111 @@
+package szproduct
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Profile is a named pair of GetLicense and GetVersion documents.
+*/
+type Profile struct {
+	License string // The GetLicense document.
+	Name    string
+	Version string // The GetVersion document.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Names of the built-in profiles.
+const (
+	ProfileExpiredEvalLicense = "expired-eval-license" // A 4.x SDK whose evaluation license expired on 2024-01-31.
+	ProfileRecordLimitReached = "record-limit-reached" // A 4.x SDK whose license allows no more records.
+	ProfileV3                 = "v3.x"                 // A 3.x SDK with a standard license.
+	ProfileV4                 = "v4.x"                 // A 4.x SDK with a standard license.
+)
+
+// The native error code of invalid messages, typed as a bad input error.
+const badInputErrorCode = 2
+
+const (
+	v3Version = `{"PRODUCT_NAME":"Senzing API","VERSION":"3.12.8","BUILD_VERSION":"3.12.8.25121",` +
+		`"BUILD_DATE":"2025-05-01","BUILD_NUMBER":"2025_05_01__07_42",` +
+		`"COMPATIBILITY_VERSION":{"CONFIG_VERSION":"10"},` +
+		`"SCHEMA_VERSION":{"ENGINE_SCHEMA_VERSION":"3.0","MINIMUM_REQUIRED_SCHEMA_VERSION":"3.0",` +
+		`"MAXIMUM_REQUIRED_SCHEMA_VERSION":"3.99"}}`
+	v4Version = `{"PRODUCT_NAME":"Senzing SDK","VERSION":"4.1.0","BUILD_VERSION":"4.1.0.25279",` +
+		`"BUILD_DATE":"2025-10-06","BUILD_NUMBER":"2025_10_06__10_00",` +
+		`"COMPATIBILITY_VERSION":{"CONFIG_VERSION":"11"},` +
+		`"SCHEMA_VERSION":{"ENGINE_SCHEMA_VERSION":"4.0","MINIMUM_REQUIRED_SCHEMA_VERSION":"4.0",` +
+		`"MAXIMUM_REQUIRED_SCHEMA_VERSION":"4.99"}}`
+	standardLicense = `{"customer":"Senzing Public Test License","contract":"Senzing Public Test License",` +
+		`"issueDate":"2025-01-01","licenseType":"STANDARD","licenseLevel":"STANDARD","billing":"YEARLY",` +
+		`"expireDate":"2099-12-31","recordLimit":1000000}`
+	expiredEvalLicense = `{"customer":"Senzing Public Test License","contract":"Senzing Public Test License",` +
+		`"issueDate":"2023-11-01","licenseType":"EVAL (Solely for non-productive use)","licenseLevel":"STANDARD",` +
+		`"billing":"MONTHLY","expireDate":"2024-01-31","recordLimit":50000}`
+	limitedLicense = `{"customer":"Senzing Public Test License","contract":"Senzing Public Test License",` +
+		`"issueDate":"2025-01-01","licenseType":"EVAL (Solely for non-productive use)","licenseLevel":"STANDARD",` +
+		`"billing":"MONTHLY","expireDate":"2099-12-31","recordLimit":0}`
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var profiles = []Profile{
+	{Name: ProfileExpiredEvalLicense, License: expiredEvalLicense, Version: v4Version},
+	{Name: ProfileRecordLimitReached, License: limitedLicense, Version: v4Version},
+	{Name: ProfileV3, License: standardLicense, Version: v3Version},
+	{Name: ProfileV4, License: standardLicense, Version: v4Version},
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Function GetProfile returns a built-in profile.
+An unknown name is a bad input error.
+
+Input
+  - name: The name of the profile, e.g. ProfileV4.
+
+Output
+  - The profile.
+*/
+func GetProfile(name string) (Profile, error) {
+	index := slices.IndexFunc(profiles, func(aProfile Profile) bool { return aProfile.Name == name })
+	if index < 0 {
+		message, _ := json.Marshal(struct {
+			Reason string `json:"reason"`
+		}{
+			Reason: fmt.Sprintf("Unknown product profile '%s'; expected one of %v", name, GetProfileNames()),
+		})
+
+		return Profile{}, szerror.New(badInputErrorCode, string(message))
+	}
+
+	return profiles[index], nil
+}
+
+/*
+Function GetProfileNames returns the names of the built-in profiles, sorted.
+*/
+func GetProfileNames() []string {
+	result := make([]string, 0, len(profiles))
+	for _, aProfile := range profiles {
+		result = append(result, aProfile.Name)
+	}
+
+	return result
+}
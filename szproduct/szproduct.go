@@ -5,19 +5,21 @@ package szproduct
 
 import (
 	"context"
-	"time"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
-	"github.com/senzing-garage/go-observing/notifier"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/sz-sdk-go-mock/clock"
+	"github.com/senzing-garage/sz-sdk-go-mock/faults"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/senzing-garage/sz-sdk-go/szproduct"
 )
 
 type Szproduct struct {
+	Clock            clock.Clock
+	Faults           *faults.Injector
 	GetLicenseResult string
 	GetVersionResult string
 	isTrace          bool
@@ -51,15 +53,26 @@ func (client *Szproduct) Destroy(ctx context.Context) error {
 	if client.isTrace {
 		client.traceEntry(3)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(4, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(4, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
+	err = client.Faults.Inject(ctx, "destroy")
+
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8001,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -86,17 +99,29 @@ func (client *Szproduct) GetLicense(ctx context.Context) (string, error) {
 	if client.isTrace {
 		client.traceEntry(9)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(10, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(10, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetLicenseResult
+	err = client.Faults.Inject(ctx, "getLicense")
+	if err == nil {
+		result = client.GetLicenseResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8003,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -121,17 +146,29 @@ func (client *Szproduct) GetVersion(ctx context.Context) (string, error) {
 	if client.isTrace {
 		client.traceEntry(11)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(12, result, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(12, result, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
-	result = client.GetVersionResult
+	err = client.Faults.Inject(ctx, "getVersion")
+	if err == nil {
+		result = client.GetVersionResult
+	}
 
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8004,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -170,9 +207,11 @@ func (client *Szproduct) RegisterObserver(ctx context.Context, observer observer
 	if client.isTrace {
 		client.traceEntry(703, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(704, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers == nil {
@@ -186,7 +225,16 @@ func (client *Szproduct) RegisterObserver(ctx context.Context, observer observer
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8702,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -206,9 +254,9 @@ func (client *Szproduct) SetLogLevel(ctx context.Context, logLevelName string) e
 	if client.isTrace {
 		client.traceEntry(705, logLevelName)
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
+		defer func() { client.traceExit(706, logLevelName, err, client.getClock().Now().Sub(entryTime)) }()
 	}
 
 	if !logging.IsValidLogLevelName(logLevelName) {
@@ -223,7 +271,16 @@ func (client *Szproduct) SetLogLevel(ctx context.Context, logLevelName string) e
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
+			helper.Notify(
+				ctx,
+				client.getClock(),
+				client.observers,
+				client.observerOrigin,
+				ComponentID,
+				8703,
+				err,
+				details,
+			)
 		}()
 	}
 
@@ -255,9 +312,11 @@ func (client *Szproduct) UnregisterObserver(ctx context.Context, observer observ
 	if client.isTrace {
 		client.traceEntry(707, observer.GetObserverID(ctx))
 
-		entryTime := time.Now()
+		entryTime := client.getClock().Now()
 
-		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
+		defer func() {
+			client.traceExit(708, observer.GetObserverID(ctx), err, client.getClock().Now().Sub(entryTime))
+		}()
 	}
 
 	if client.observers != nil {
@@ -268,7 +327,7 @@ func (client *Szproduct) UnregisterObserver(ctx context.Context, observer observ
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
+		helper.Notify(ctx, client.getClock(), client.observers, client.observerOrigin, ComponentID, 8704, err, details)
 		err = client.observers.UnregisterObserver(ctx, observer)
 
 		if !client.observers.HasObservers(ctx) {
@@ -283,6 +342,17 @@ func (client *Szproduct) UnregisterObserver(ctx context.Context, observer observ
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// --- Time -------------------------------------------------------------------
+
+// Get the clock of the client, the real clock if none is set.
+func (client *Szproduct) getClock() clock.Clock {
+	if client.Clock == nil {
+		return clock.Real{}
+	}
+
+	return client.Clock
+}
+
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
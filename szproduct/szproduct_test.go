@@ -2,6 +2,7 @@ package szproduct_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/senzing-garage/sz-sdk-go-mock/szproduct"
 	"github.com/senzing-garage/sz-sdk-go-mock/testdata"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -61,6 +63,60 @@ func TestSzproduct_GetVersion(test *testing.T) {
 	printActual(test, actual)
 }
 
+// ----------------------------------------------------------------------------
+// Profiles
+// ----------------------------------------------------------------------------
+
+func TestSzproduct_GetProfile(test *testing.T) {
+	test.Parallel()
+
+	testCases := []struct {
+		name          string
+		configVersion string
+		expireDate    string
+		recordLimit   int64
+	}{
+		{name: szproduct.ProfileExpiredEvalLicense, configVersion: "11", expireDate: "2024-01-31", recordLimit: 50000},
+		{name: szproduct.ProfileRecordLimitReached, configVersion: "11", expireDate: "2099-12-31", recordLimit: 0},
+		{name: szproduct.ProfileV3, configVersion: "10", expireDate: "2099-12-31", recordLimit: 1000000},
+		{name: szproduct.ProfileV4, configVersion: "11", expireDate: "2099-12-31", recordLimit: 1000000},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			profile, err := szproduct.GetProfile(testCase.name)
+			require.NoError(test, err)
+
+			version := &struct {
+				CompatibilityVersion struct {
+					ConfigVersion string `json:"CONFIG_VERSION"`
+				} `json:"COMPATIBILITY_VERSION"`
+			}{}
+			require.NoError(test, json.Unmarshal([]byte(profile.Version), version))
+			assert.Equal(test, testCase.configVersion, version.CompatibilityVersion.ConfigVersion)
+
+			license := &struct {
+				ExpireDate  string `json:"expireDate"`
+				RecordLimit int64  `json:"recordLimit"`
+			}{}
+			require.NoError(test, json.Unmarshal([]byte(profile.License), license))
+			assert.Equal(test, testCase.expireDate, license.ExpireDate)
+			assert.Equal(test, testCase.recordLimit, license.RecordLimit)
+		})
+	}
+
+	assert.Len(test, szproduct.GetProfileNames(), len(testCases))
+}
+
+func TestSzproduct_GetProfile_unknown(test *testing.T) {
+	test.Parallel()
+
+	_, err := szproduct.GetProfile("v2.x")
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
 // ----------------------------------------------------------------------------
 // Logging and observing
 // ----------------------------------------------------------------------------
@@ -168,6 +224,7 @@ func getSzAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
 		WhyEntitiesResult:                       testValue.String("WhyEntitiesResult"),
 		WhyRecordInEntityResult:                 testValue.String("WhyRecordInEntityResult"),
 		WhyRecordsResult:                        testValue.String("WhyRecordsResult"),
+		WhySearchResult:                         testValue.String("WhySearchResult"),
 	}
 
 	return result
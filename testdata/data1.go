@@ -40,6 +40,7 @@ var Data1_strings = map[string]string{
 	"WhyEntitiesResult":                       `{"WHY_RESULTS":[{"ENTITY_ID":100001,"ENTITY_ID_2":100001,"MATCH_INFO":{"WHY_KEY":"+NAME+DOB+ADDRESS+PHONE+EMAIL","WHY_ERRULE_CODE":"SF1_SNAME_CFF_CSTAB","MATCH_LEVEL_CODE":"RESOLVED"}}],"ENTITIES":[{"RESOLVED_ENTITY":{"ENTITY_ID":100001}}]}`,
 	"WhyRecordInEntityResult":                 `{"WHY_RESULTS":[{"INTERNAL_ID":100001,"ENTITY_ID":100001,"FOCUS_RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}],"MATCH_INFO":{"WHY_KEY":"+NAME+DOB+PHONE","WHY_ERRULE_CODE":"CNAME_CFF_CEXCL","MATCH_LEVEL_CODE":"RESOLVED"}}],"ENTITIES":[{"RESOLVED_ENTITY":{"ENTITY_ID":100001}}]}`,
 	"WhyRecordsResult":                        `{"ENTITIES":[{"RESOLVED_ENTITY":{"ENTITY_ID":100001}}...`,
+	"WhySearchResult":                         `{"WHY_RESULTS":[{"ENTITY_ID":100001,"MATCH_INFO":{"WHY_KEY":"+NAME+SSN","WHY_ERRULE_CODE":"SF1_PNAME_CSTAB","MATCH_LEVEL_CODE":"RESOLVED"}}],"ENTITIES":[{"RESOLVED_ENTITY":{"ENTITY_ID":100001}}]}`,
 }
 
 var Data1_int64s_example = map[string]int64{